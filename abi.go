@@ -0,0 +1,49 @@
+package main
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"runtime/debug"
+)
+
+// Version effective d'un module (remplacement compris)
+func moduleVersion(module *debug.Module) string {
+	if module.Replace != nil {
+		return module.Replace.Version
+	}
+	return module.Version
+}
+
+// Écarts de compilation empêchant Go de charger un plugin : même version de Go
+// et mêmes versions des modules partagés avec l'hôte
+func abiMismatches(host, plugin *debug.BuildInfo) []string {
+	var mismatches []string
+	if plugin.GoVersion != host.GoVersion {
+		mismatches = append(mismatches, fmt.Sprintf("%s (Pannel: %s)", plugin.GoVersion, host.GoVersion))
+	}
+
+	hostDeps := make(map[string]string)
+	for _, dep := range host.Deps {
+		hostDeps[dep.Path] = moduleVersion(dep)
+	}
+	for _, dep := range plugin.Deps {
+		hostVersion, shared := hostDeps[dep.Path]
+		if shared && moduleVersion(dep) != hostVersion {
+			mismatches = append(mismatches, fmt.Sprintf("%s %s (Pannel: %s)", dep.Path, moduleVersion(dep), hostVersion))
+		}
+	}
+	return mismatches
+}
+
+// Vérifier sans le charger qu'un plugin compilé est compatible avec le binaire en cours
+func checkPluginABI(path string) ([]string, error) {
+	host, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil, fmt.Errorf("informations de compilation de Pannel indisponibles")
+	}
+	plugin, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("informations de compilation illisibles: %v", err)
+	}
+	return abiMismatches(host, plugin), nil
+}
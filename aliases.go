@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Bornes du bloc d'alias généré dans le fichier d'alias de chaque shell (les lignes hors du bloc appartiennent à l'utilisateur)
+const (
+	aliasBlockBegin = "# >>> Pannel : alias des plugins installés (bloc généré, ne pas modifier) >>>"
+	aliasBlockEnd   = "# <<< Pannel <<<"
+)
+
+// Commande lancée par l'alias d'un plugin : Chargeur s'il est installé, sinon "Pannel run"
+func aliasCommand(filename, pluginDir string) string {
+	baseDir := filepath.Dir(pluginDir)
+	chargeurFile := filepath.Join(baseDir, "Chargeur")
+	if info, err := os.Stat(chargeurFile); err == nil && info.Mode()&0111 != 0 {
+		return fmt.Sprintf("%s %s/%s", chargeurFile, pluginDir, filename)
+	}
+
+	pannel := "Pannel"
+	if exe, err := os.Executable(); err == nil {
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			pannel = resolved
+		}
+	}
+	return fmt.Sprintf("%s -c %s run %s", pannel, baseDir, pluginName(filename))
+}
+
+// Ligne d'alias attendue dans le fichier d'alias d'un shell pour un plugin
+func aliasDefinition(shell shellSpec, name, filename, pluginDir string) string {
+	return shell.alias(name, aliasCommand(filename, pluginDir))
+}
+
+// Nom et commande d'une ligne "alias nom='commande'"
+func parseAlias(line string) (name string, value string, ok bool) {
+	definition, ok := strings.CutPrefix(line, "alias ")
+	if !ok {
+		return "", "", false
+	}
+	return strings.Cut(definition, "=")
+}
+
+// Alias ajouté ligne à ligne par une ancienne version de Pannel (repris dans le bloc généré)
+func isLegacyAlias(line string) bool {
+	name, value, ok := parseAlias(line)
+	if !ok {
+		return false
+	}
+	return strings.Contains(value, "/Chargeur ") || strings.HasSuffix(value, " run "+name+"'")
+}
+
+// Régénération du fichier des alias d'un shell
+type aliasPlan struct {
+	shell   string
+	path    string
+	current string   // Contenu actuel ("" si absent)
+	content string   // Contenu régénéré
+	added   []string // Alias ajoutés
+	removed []string // Alias retirés (plugin absent)
+	updated []string // Alias dont la commande change
+}
+
+// Le fichier doit être réécrit
+func (p aliasPlan) changed() bool {
+	return p.current != p.content
+}
+
+// Résumé des changements pour les logs
+func (p aliasPlan) summary() string {
+	var parts []string
+	if len(p.added) > 0 {
+		parts = append(parts, "ajoutés: "+strings.Join(p.added, ", "))
+	}
+	if len(p.updated) > 0 {
+		parts = append(parts, "mis à jour: "+strings.Join(p.updated, ", "))
+	}
+	if len(p.removed) > 0 {
+		parts = append(parts, "retirés: "+strings.Join(p.removed, ", "))
+	}
+	if len(parts) == 0 {
+		if p.changed() {
+			return "fichier remis en forme"
+		}
+		return "déjà à jour"
+	}
+	return strings.Join(parts, " ; ")
+}
+
+// Calculer le contenu du fichier d'alias d'un shell à partir des plugins présents dans pluginDir.
+// Le bloc généré est trié pour un résultat identique d'une exécution à l'autre.
+func planAliases(shell shellSpec, pluginDir string) (aliasPlan, error) {
+	plan := aliasPlan{shell: shell.name, path: shell.aliasPath(filepath.Dir(pluginDir))}
+
+	data, err := os.ReadFile(plan.path)
+	if err != nil && !os.IsNotExist(err) {
+		return plan, err
+	}
+	plan.current = string(data)
+
+	files, err := installedPluginFiles(pluginDir)
+	if err != nil && !os.IsNotExist(err) {
+		return plan, err
+	}
+	sort.Strings(files)
+
+	// Alias refusés (conflit) absents du bloc
+	names, _, err := resolveAliasNames(files, filepath.Dir(pluginDir))
+	if err != nil {
+		return plan, err
+	}
+
+	// Lignes de l'utilisateur autour du bloc, alias générés précédemment
+	var before, after []string
+	previous := make(map[string]string)
+	inBlock, seenBlock := false, false
+	lines := strings.Split(strings.TrimSuffix(plan.current, "\n"), "\n")
+	if plan.current == "" {
+		lines = nil
+	}
+	for _, line := range lines {
+		switch {
+		case line == aliasBlockBegin:
+			inBlock, seenBlock = true, true
+		case line == aliasBlockEnd:
+			inBlock = false
+		case inBlock || isLegacyAlias(line):
+			if name, ok := shell.aliasName(line); ok {
+				previous[name] = line
+			}
+		case seenBlock:
+			after = append(after, line)
+		default:
+			before = append(before, line)
+		}
+	}
+
+	block := []string{aliasBlockBegin}
+	generated := make(map[string]bool)
+	for _, file := range files {
+		name, ok := names[file]
+		if !ok {
+			continue
+		}
+		line := aliasDefinition(shell, name, file, pluginDir)
+		block = append(block, line)
+		generated[name] = true
+
+		if old, ok := previous[name]; !ok {
+			plan.added = append(plan.added, name)
+		} else if old != line {
+			plan.updated = append(plan.updated, name)
+		}
+	}
+	block = append(block, aliasBlockEnd)
+
+	for name := range previous {
+		if !generated[name] {
+			plan.removed = append(plan.removed, name)
+		}
+	}
+	sort.Strings(plan.removed)
+
+	all := append(append(before, block...), after...)
+	plan.content = strings.Join(all, "\n") + "\n"
+	return plan, nil
+}
+
+// Écrire le plan (fichier temporaire puis renommage, droits conservés)
+func (p aliasPlan) apply() error {
+	if !p.changed() {
+		return nil
+	}
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(p.path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(p.content), mode); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, mode); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, p.path)
+}
+
+// Reconstruire les alias de chaque shell configuré à partir des plugins installés
+func regenerateAliases(pluginDir string) ([]aliasPlan, error) {
+	var plans []aliasPlan
+	for _, shell := range activeShells(filepath.Dir(pluginDir)) {
+		plan, err := planAliases(shell, pluginDir)
+		if err == nil {
+			err = plan.apply()
+		}
+		if err != nil {
+			return plans, fmt.Errorf("%s: %v", shell.name, err)
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// Pannel aliases [--dry-run] : régénérer les fichiers d'alias
+func cmdAliases(ctx *cliContext, dryRun bool) int {
+	code := exitOK
+	conflicts, err := aliasConflicts(ctx.pluginDir)
+	if err != nil {
+		return exitWithError(err)
+	}
+	for _, conflict := range conflicts {
+		fmt.Fprintf(os.Stderr, "Attention: %s\n", conflict)
+	}
+
+	for _, shell := range activeShells(ctx.baseDir) {
+		plan, err := planAliases(shell, ctx.pluginDir)
+		if err == nil && !dryRun {
+			err = plan.apply()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Erreur: %s: %v\n", shell.name, err)
+			code = exitError
+			continue
+		}
+		fmt.Printf("%s : %s\n", plan.path, plan.summary())
+		if dryRun && plan.changed() {
+			fmt.Print("\n" + plan.content + "\n")
+		}
+	}
+	return code
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// Noms d'alias choisis par l'utilisateur (baseDir/aliases.json), clé: nom du fichier du plugin
+type aliasOverrides struct {
+	Aliases map[string]string `json:"aliases"`
+}
+
+func aliasOverridesPath(baseDir string) string {
+	return filepath.Join(baseDir, "aliases.json")
+}
+
+// Lire les noms d'alias personnalisés
+func loadAliasOverrides(baseDir string) (aliasOverrides, error) {
+	overrides := aliasOverrides{Aliases: make(map[string]string)}
+
+	data, err := os.ReadFile(aliasOverridesPath(baseDir))
+	if os.IsNotExist(err) {
+		return overrides, nil
+	}
+	if err != nil {
+		return overrides, err
+	}
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return overrides, fmt.Errorf("erreur parsing aliases.json: %v", err)
+	}
+	if overrides.Aliases == nil {
+		overrides.Aliases = make(map[string]string)
+	}
+	return overrides, nil
+}
+
+func saveAliasOverrides(overrides aliasOverrides, baseDir string) error {
+	data, err := json.MarshalIndent(overrides, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(aliasOverridesPath(baseDir), data, 0644)
+}
+
+// Nom utilisable comme alias bash/zsh et comme fonction fish
+var aliasNamePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+
+// Choisir le nom de l'alias d'un plugin ("" = revenir au nom du fichier)
+func setAliasOverride(filename string, name string, baseDir string) error {
+	if name != "" && !aliasNamePattern.MatchString(name) {
+		return fmt.Errorf("nom d'alias invalide: %q", name)
+	}
+	overrides, err := loadAliasOverrides(baseDir)
+	if err != nil {
+		return err
+	}
+	if name == "" || name == pluginName(filename) {
+		delete(overrides.Aliases, filename)
+	} else {
+		overrides.Aliases[filename] = name
+	}
+	return saveAliasOverrides(overrides, baseDir)
+}
+
+// Noms réservés : alias d'ouverture du panel et binaire de Pannel
+var reservedAliasNames = map[string]bool{
+	"Plugin":    true,
+	programName: true,
+}
+
+// Commandes internes et mots-clés de bash, zsh et fish : un alias portant ce nom casserait le shell
+var shellBuiltins = map[string]bool{}
+
+func init() {
+	for _, name := range []string{
+		// bash
+		"alias", "bg", "bind", "break", "builtin", "caller", "cd", "command", "compgen", "complete", "compopt",
+		"continue", "declare", "dirs", "disown", "echo", "enable", "eval", "exec", "exit", "export", "false",
+		"fc", "fg", "getopts", "hash", "help", "history", "jobs", "kill", "let", "local", "logout", "mapfile",
+		"popd", "printf", "pushd", "pwd", "read", "readarray", "readonly", "return", "set", "shift", "shopt",
+		"source", "suspend", "test", "times", "trap", "true", "type", "typeset", "ulimit", "umask", "unalias",
+		"unset", "wait",
+		"if", "then", "else", "elif", "fi", "case", "esac", "for", "select", "while", "until", "do", "done",
+		"in", "function", "time", "coproc",
+		// zsh
+		"autoload", "bindkey", "emulate", "functions", "integer", "noglob", "print", "rehash", "setopt",
+		"unsetopt", "unfunction", "whence", "where", "which", "zle", "zmodload", "zstyle",
+		// fish
+		"and", "or", "not", "begin", "end", "switch", "abbr", "argparse", "contains", "count", "emit",
+		"funced", "funcsave", "math", "random", "set_color", "status", "string",
+	} {
+		shellBuiltins[name] = true
+	}
+}
+
+// Alias refusé, ou accepté bien qu'il masque une commande
+type aliasConflict struct {
+	filename string
+	name     string
+	reason   string // Ce que l'alias masquerait
+	refused  bool   // Alias non généré
+}
+
+func (c aliasConflict) String() string {
+	if c.refused {
+		return fmt.Sprintf("alias %s non créé pour %s : %s", c.name, c.filename, c.reason)
+	}
+	return fmt.Sprintf("l'alias %s de %s masque la %s", c.name, c.filename, c.reason)
+}
+
+// Nom de l'alias de chaque plugin (clé: nom du fichier) et conflits détectés.
+// Un nom de fichier déjà pris par une commande est refusé ; un nom choisi par l'utilisateur
+// est conservé avec un avertissement. Les commandes internes et les doublons sont toujours refusés.
+func resolveAliasNames(files []string, baseDir string) (map[string]string, []aliasConflict, error) {
+	overrides, err := loadAliasOverrides(baseDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sorted := append([]string(nil), files...)
+	sort.Strings(sorted)
+
+	names := make(map[string]string)
+	owners := make(map[string]string) // Alias déjà attribué, clé: nom de l'alias
+	var conflicts []aliasConflict
+	for _, file := range sorted {
+		name, custom := overrides.Aliases[file]
+		if !custom {
+			name = pluginName(file)
+		}
+
+		conflict := aliasConflict{filename: file, name: name}
+		switch {
+		case !aliasNamePattern.MatchString(name):
+			conflict.reason, conflict.refused = "nom invalide", true
+		case reservedAliasNames[name]:
+			conflict.reason, conflict.refused = "nom réservé à Pannel", true
+		case shellBuiltins[name]:
+			conflict.reason, conflict.refused = "commande interne du shell", true
+		case owners[name] != "":
+			conflict.reason, conflict.refused = "déjà utilisé par "+owners[name], true
+		default:
+			if path, err := exec.LookPath(name); err == nil {
+				conflict.reason, conflict.refused = "commande système "+path, !custom
+			}
+		}
+
+		if conflict.reason != "" {
+			conflicts = append(conflicts, conflict)
+		}
+		if conflict.refused {
+			continue
+		}
+		names[file] = name
+		owners[name] = file
+	}
+	return names, conflicts, nil
+}
+
+// Alias générés des plugins installés (clé: nom du fichier ; absent si l'alias est refusé)
+func installedAliasNames(pluginDir string) (map[string]string, error) {
+	files, err := installedPluginFiles(pluginDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	names, _, err := resolveAliasNames(files, filepath.Dir(pluginDir))
+	return names, err
+}
+
+// Conflits des alias des plugins installés
+func aliasConflicts(pluginDir string) ([]aliasConflict, error) {
+	files, err := installedPluginFiles(pluginDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	_, conflicts, err := resolveAliasNames(files, filepath.Dir(pluginDir))
+	return conflicts, err
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveAliasNames(t *testing.T) {
+	// Seules commandes système connues : outil et disque
+	bin := t.TempDir()
+	for _, name := range []string{"outil", "disque"} {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bin)
+
+	baseDir := t.TempDir()
+	overrides := aliasOverrides{Aliases: map[string]string{
+		"Reseau.so":  "net",
+		"Reseau2.so": "net",       // Doublon : le premier fichier (tri par nom) garde l'alias
+		"Panel.so":   "Plugin",    // Réservé à Pannel
+		"Shell.so":   "cd",        // Commande interne
+		"Espace.so":  "mon alias", // Nom invalide
+		"Choix.so":   "outil",     // Choisi malgré la commande système
+	}}
+	if err := saveAliasOverrides(overrides, baseDir); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []string{"Reseau2.so", "Reseau.so", "Journal.so", "Panel.so", "Shell.so", "Espace.so", "Choix.so", "outil.so", "disque.so", "test.so"}
+	names, conflicts, err := resolveAliasNames(files, baseDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantNames := map[string]string{
+		"Journal.so": "Journal",
+		"Reseau.so":  "net",
+		"Choix.so":   "outil",
+	}
+	if len(names) != len(wantNames) {
+		t.Errorf("alias %v, attendu %v", names, wantNames)
+	}
+	for file, want := range wantNames {
+		if names[file] != want {
+			t.Errorf("alias de %s = %q, attendu %q", file, names[file], want)
+		}
+	}
+
+	wantConflicts := map[string]aliasConflict{
+		"Reseau2.so": {name: "net", reason: "déjà utilisé par Reseau.so", refused: true},
+		"Panel.so":   {name: "Plugin", reason: "nom réservé à Pannel", refused: true},
+		"Shell.so":   {name: "cd", reason: "commande interne du shell", refused: true},
+		"Espace.so":  {name: "mon alias", reason: "nom invalide", refused: true},
+		"Choix.so":   {name: "outil", reason: "commande système " + filepath.Join(bin, "outil"), refused: false},
+		"outil.so":   {name: "outil", reason: "déjà utilisé par Choix.so", refused: true},
+		"disque.so":  {name: "disque", reason: "commande système " + filepath.Join(bin, "disque"), refused: true},
+		"test.so":    {name: "test", reason: "commande interne du shell", refused: true},
+	}
+	if len(conflicts) != len(wantConflicts) {
+		t.Errorf("%d conflits, attendu %d: %v", len(conflicts), len(wantConflicts), conflicts)
+	}
+	for _, c := range conflicts {
+		want, ok := wantConflicts[c.filename]
+		want.filename = c.filename
+		if !ok || c != want {
+			t.Errorf("conflit %+v, attendu %+v", c, want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Traitements du panel 1 : Entrée (selon l'état de chaque plugin), "i" (installer), "x" (supprimer)
+const (
+	batchAll = iota
+	batchInstall
+	batchRemove
+)
+
+// Opérations d'un traitement, dans l'ordre du résumé
+const (
+	opInstall = "install"
+	opUpdate  = "update"
+	opRemove  = "remove"
+)
+
+var opOrder = map[string]int{opInstall: 0, opUpdate: 1, opRemove: 2}
+
+// Regroupement des opérations dans le résumé de la confirmation
+var opGroups = map[string]string{
+	opInstall: "à installer",
+	opUpdate:  "à mettre à jour",
+	opRemove:  "à supprimer",
+}
+
+// Plugin à traiter et son opération
+type batchItem struct {
+	repoIdx int
+	fileIdx int
+	op      string
+}
+
+// Opération d'un plugin pour le traitement demandé ("" : rien à faire)
+func (m model) batchOp(mode int, repo Repository, file GitHubFile) string {
+	local := m.localFiles[localKey(repo.Name, file.Name)]
+	state := pluginState(repo, file, local, m.installed)
+	updatable := state == stateUpdatable
+	switch {
+	case state == stateForeign:
+		// Installé depuis un autre dépôt : ni installation ni suppression depuis celui-ci
+		return ""
+	case !local && mode != batchRemove:
+		return opInstall
+	case local && updatable && mode != batchRemove:
+		return opUpdate
+	case local && mode != batchInstall:
+		return opRemove
+	}
+	return ""
+}
+
+// Opérations des plugins sélectionnés (ou du plugin sous le curseur sans sélection), triées par opération puis par nom
+func (m model) planBatch(mode int) []batchItem {
+	keys := make([]string, 0, len(m.selected))
+	for key := range m.selected {
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 && mode != batchAll {
+		if line := m.displayLines[m.cursor]; !line.isHeader {
+			keys = append(keys, fmt.Sprintf("%d:%d", line.repoIdx, line.fileIdx))
+		}
+	}
+
+	var items []batchItem
+	for _, key := range keys {
+		var repoIdx, fileIdx int
+		if _, err := fmt.Sscanf(key, "%d:%d", &repoIdx, &fileIdx); err != nil {
+			continue
+		}
+		if repoIdx >= len(m.repos) || fileIdx >= len(m.repos[repoIdx].Files) {
+			continue
+		}
+		if op := m.batchOp(mode, m.repos[repoIdx], m.repos[repoIdx].Files[fileIdx]); op != "" {
+			items = append(items, batchItem{repoIdx: repoIdx, fileIdx: fileIdx, op: op})
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].op != items[j].op {
+			return opOrder[items[i].op] < opOrder[items[j].op]
+		}
+		a, b := m.repos[items[i].repoIdx], m.repos[items[j].repoIdx]
+		if a.Files[items[i].fileIdx].Name != b.Files[items[j].fileIdx].Name {
+			return a.Files[items[i].fileIdx].Name < b.Files[items[j].fileIdx].Name
+		}
+		return a.Name < b.Name
+	})
+	return items
+}
+
+// Demander confirmation des opérations, chacune pouvant être décochée
+func (m model) confirmBatch(mode int) (tea.Model, tea.Cmd) {
+	items := m.planBatch(mode)
+	if len(items) == 0 {
+		switch mode {
+		case batchInstall:
+			m.addLog("⚠️ Aucun plugin à installer ou mettre à jour")
+		case batchRemove:
+			m.addLog("⚠️ Aucun plugin installé à supprimer")
+		default:
+			m.addLog("⚠️ Aucune opération à effectuer")
+		}
+		return m, nil
+	}
+
+	// Un même nom de fichier ne peut venir que d'un dépôt
+	downloads := make(map[string]string) // Dépôt de chaque téléchargement, clé: nom du fichier
+	for _, item := range items {
+		repo := m.repos[item.repoIdx]
+		file := repo.Files[item.fileIdx]
+		if item.op == opRemove {
+			continue
+		}
+		if other, ok := downloads[file.Name]; ok {
+			m.addLog(fmt.Sprintf("⛔ %s sélectionné dans %s et %s : un seul peut être installé", file.Name, other, repo.Name))
+			return m, nil
+		}
+		downloads[file.Name] = repo.Name
+	}
+
+	list := &dialogList{}
+	for _, item := range items {
+		repo := m.repos[item.repoIdx]
+		list.items = append(list.items, dialogListItem{
+			group:   opGroups[item.op],
+			label:   fmt.Sprintf("%-16s %s (%s)", opGroups[item.op], repo.Files[item.fileIdx].Name, repo.Name),
+			checked: true,
+		})
+	}
+	m.dialog = &dialog{
+		title: "Confirmer les opérations",
+		lines: []string{fmt.Sprintf("%s, %s : naviguer, %s : cocher/décocher", m.keys.keyLabel(actUp), m.keys.keyLabel(actDown), m.keys.keyLabel(actToggle)), ""},
+		list:  list,
+		options: []dialogOption{
+			m.confirmOption("Confirmer", func(m model) (tea.Model, tea.Cmd) {
+				var confirmed []batchItem
+				for _, i := range list.checkedIndexes() {
+					confirmed = append(confirmed, items[i])
+				}
+				return m.startProcessing(confirmed)
+			}),
+			m.cancelOption(),
+		},
+	}
+	return m, nil
+}
+
+// Lancer le traitement confirmé, en demandant le sort des données des plugins supprimés
+func (m model) startProcessing(items []batchItem) (tea.Model, tea.Cmd) {
+	if len(items) == 0 {
+		m.addLog("⚠️ Aucune opération confirmée")
+		return m, nil
+	}
+
+	var withData []string
+	for _, item := range items {
+		file := m.repos[item.repoIdx].Files[item.fileIdx]
+		if item.op == opRemove && hasPluginData(pluginName(file.Name), filepath.Dir(m.pluginDir)) {
+			withData = append(withData, file.Name)
+		}
+	}
+
+	if len(withData) == 0 {
+		return m.runProcessing(items, false)
+	}
+
+	lines := []string{"Ces plugins vont être supprimés mais ont des données :", ""}
+	for _, name := range withData {
+		lines = append(lines, "  • "+name)
+	}
+	m.dialog = &dialog{
+		title: "Données des plugins",
+		lines: lines,
+		options: []dialogOption{
+			{keys: []string{"k"}, label: "Conserver", action: func(m model) (tea.Model, tea.Cmd) { return m.runProcessing(items, false) }},
+			{keys: []string{"p"}, label: "Purger", action: func(m model) (tea.Model, tea.Cmd) { return m.runProcessing(items, true) }},
+			m.cancelOption(),
+		},
+	}
+	return m, nil
+}
+
+// Exécuter les opérations confirmées
+func (m model) runProcessing(items []batchItem, purgeData bool) (tea.Model, tea.Cmd) {
+	m.purgeData = purgeData
+	m.processing = true
+	m.pending = len(items)
+	m.statusMsg = fmt.Sprintf("Traitement de %d Plugin(s)...", len(items))
+	m.addLog(fmt.Sprintf("🚀 Démarrage du traitement de %d Plugin(s)", len(items)))
+	return m, processSelectedFiles(m, items)
+}
+
+// Traiter les opérations : téléchargement (installation, mise à jour) ou suppression.
+// Chacune envoie son operationCompleteMsg ; la dernière termine le lot (m.pending).
+func processSelectedFiles(m model, items []batchItem) tea.Cmd {
+	var cmds []tea.Cmd
+
+	for _, item := range items {
+		repo := m.repos[item.repoIdx]
+		file := repo.Files[item.fileIdx]
+
+		if item.op == opRemove {
+			cmds = append(cmds, deleteFile(file.Name, m.pluginDir, m.purgeData))
+		} else {
+			cmds = append(cmds, downloadPlugin(repo, file, m.pluginDir))
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
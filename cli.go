@@ -0,0 +1,455 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Codes de sortie des commandes
+const (
+	exitOK       = 0
+	exitError    = 1 // Échec d'une opération (réseau, disque, signature...)
+	exitUsage    = 2 // Arguments invalides
+	exitNotFound = 3 // Plugin introuvable dans les dépôts
+)
+
+var errPluginNotFound = errors.New("plugin introuvable")
+
+// Plugin d'un dépôt
+type repoPlugin struct {
+	repo Repository
+	file GitHubFile
+}
+
+// Charger les dépôts configurés (même source que le TUI) et les erreurs des dépôts ignorés
+func loadRepos(ctx *cliContext) ([]Repository, []error, error) {
+	ctx.logf("configuration: %s", ctx.configPath)
+	msg := fetchFiles(ctx.configPath)().(filesLoadedMsg)
+	return msg.repos, msg.repoErrors, msg.err
+}
+
+// Signaler les dépôts ignorés sans interrompre la commande
+func warnRepoErrors(repoErrors []error) {
+	for _, err := range repoErrors {
+		fmt.Fprintf(os.Stderr, "Attention: dépôt ignoré: %v\n", err)
+	}
+}
+
+// Trouver un plugin par nom : "foo", "foo.so" ou "<dépôt>:foo.so" en cas d'ambiguïté
+func findPlugin(repos []Repository, query string) (repoPlugin, error) {
+	repoName := ""
+	if idx := strings.LastIndex(query, ":"); idx >= 0 {
+		repoName, query = query[:idx], query[idx+1:]
+	}
+
+	var matches []repoPlugin
+	for _, repo := range repos {
+		if repoName != "" && repo.Name != repoName {
+			continue
+		}
+		for _, file := range repo.Files {
+			if file.Name == query || pluginName(file.Name) == query {
+				matches = append(matches, repoPlugin{repo: repo, file: file})
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return repoPlugin{}, fmt.Errorf("%w: %s", errPluginNotFound, query)
+	}
+	if len(matches) > 1 {
+		var names []string
+		for _, match := range matches {
+			names = append(names, match.repo.Name+":"+match.file.Name)
+		}
+		return repoPlugin{}, fmt.Errorf("%s existe dans plusieurs dépôts, préciser : %s", query, strings.Join(names, ", "))
+	}
+	return matches[0], nil
+}
+
+// Afficher une erreur et choisir le code de sortie
+func exitWithError(err error) int {
+	fmt.Fprintf(os.Stderr, "Erreur: %v\n", err)
+	if errors.Is(err, errPluginNotFound) {
+		return exitNotFound
+	}
+	return exitError
+}
+
+// Afficher un tableau de plugins
+func printPlugins(plugins []repoPlugin, pluginDir string) error {
+	var repos []Repository
+	for _, p := range plugins {
+		repos = append(repos, p.repo)
+	}
+	state, err := loadInstalled(filepath.Dir(pluginDir))
+	if err != nil {
+		return err
+	}
+	localFiles := localPluginFiles(repos, pluginDir, state)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DÉPÔT\tPLUGIN\tÉTAT\tSIGNATURE")
+	for _, p := range plugins {
+		status := pluginState(p.repo, p.file, localFiles[localKey(p.repo.Name, p.file.Name)], state)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.repo.Name, p.file.Name, stateLabels[status], trustLabels[repoTrust(p.repo)])
+	}
+	return w.Flush()
+}
+
+// Pannel list : tous les plugins des dépôts
+func cmdList(ctx *cliContext, format string) int {
+	repos, repoErrors, err := loadRepos(ctx)
+	if err != nil {
+		return exitWithErrorFormat("list", format, err)
+	}
+
+	if format == outputJSON {
+		state, err := loadInstalled(ctx.baseDir)
+		if err != nil {
+			return exitWithErrorFormat("list", format, err)
+		}
+		aliases, err := installedAliasNames(ctx.pluginDir)
+		if err != nil {
+			return exitWithErrorFormat("list", format, err)
+		}
+		out := listOutput(repos, localPluginFiles(repos, ctx.pluginDir, state), state, aliases, repoErrors)
+		if err := writeJSON(os.Stdout, out); err != nil {
+			return exitWithError(err)
+		}
+		return exitOK
+	}
+
+	warnRepoErrors(repoErrors)
+	var plugins []repoPlugin
+	for _, repo := range repos {
+		for _, file := range repo.Files {
+			plugins = append(plugins, repoPlugin{repo: repo, file: file})
+		}
+	}
+
+	if err := printPlugins(plugins, ctx.pluginDir); err != nil {
+		return exitWithError(err)
+	}
+	return exitOK
+}
+
+// Pannel search <terme> : plugins dont le nom contient le terme
+func cmdSearch(ctx *cliContext, term string) int {
+	repos, repoErrors, err := loadRepos(ctx)
+	if err != nil {
+		return exitWithError(err)
+	}
+	warnRepoErrors(repoErrors)
+
+	term = strings.ToLower(term)
+	var plugins []repoPlugin
+	for _, repo := range repos {
+		for _, file := range repo.Files {
+			if strings.Contains(strings.ToLower(file.Name), term) {
+				plugins = append(plugins, repoPlugin{repo: repo, file: file})
+			}
+		}
+	}
+
+	if len(plugins) == 0 {
+		fmt.Printf("Aucun plugin ne correspond à %q\n", term)
+		return exitNotFound
+	}
+	if err := printPlugins(plugins, ctx.pluginDir); err != nil {
+		return exitWithError(err)
+	}
+	return exitOK
+}
+
+// Télécharger un plugin et ajouter son alias
+func installPlugin(p repoPlugin, pluginDir string) error {
+	msg := downloadPlugin(p.repo, p.file, pluginDir)().(operationCompleteMsg)
+	if msg.err != nil {
+		return fmt.Errorf("%s: %v", p.file.Name, msg.err)
+	}
+	if msg.verified {
+		fmt.Printf("Signature de %s vérifiée.\n", p.file.Name)
+	} else if msg.unsigned {
+		fmt.Printf("Attention: %s provient d'un dépôt non signé.\n", p.file.Name)
+	}
+
+	if _, err := regenerateAliases(pluginDir); err != nil {
+		fmt.Printf("Attention: impossible d'ajouter l'alias pour %s: %v\n", p.file.Name, err)
+	}
+	conflicts, _ := aliasConflicts(pluginDir)
+	for _, conflict := range conflicts {
+		if conflict.filename == p.file.Name {
+			fmt.Printf("Attention: %s\n", conflict)
+		}
+	}
+	return nil
+}
+
+// Pannel add <plugin>
+func cmdAdd(ctx *cliContext, query string) int {
+	repos, repoErrors, err := loadRepos(ctx)
+	if err != nil {
+		return exitWithError(err)
+	}
+	warnRepoErrors(repoErrors)
+	p, err := findPlugin(repos, query)
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	// Même nom de fichier installé depuis un autre dépôt : refusé comme dans le TUI
+	state, err := loadInstalled(ctx.baseDir)
+	if err != nil {
+		return exitWithError(err)
+	}
+	if other := state.Plugins[p.file.Name].Repo; other != "" && other != p.repo.Name {
+		return exitWithError(fmt.Errorf("%s déjà installé depuis %s, le supprimer avant d'installer celui de %s", p.file.Name, other, p.repo.Name))
+	}
+
+	if _, err := os.Stat(filepath.Join(ctx.pluginDir, p.file.Name)); err == nil {
+		fmt.Printf("%s déjà installé.\n", p.file.Name)
+		return exitOK
+	}
+
+	if err := installPlugin(p, ctx.pluginDir); err != nil {
+		return exitWithError(err)
+	}
+	fmt.Printf("%s installé depuis %s.\n", p.file.Name, p.repo.Name)
+	return exitOK
+}
+
+// Pannel remove <plugin> (les données du plugin sont conservées)
+func cmdRemove(ctx *cliContext, query string) int {
+	filename := query
+	if repos, _, err := loadRepos(ctx); err == nil {
+		if p, err := findPlugin(repos, query); err == nil {
+			filename = p.file.Name
+		}
+	}
+	// Un plugin retiré des dépôts peut toujours être supprimé par son nom de fichier
+	if _, err := os.Stat(filepath.Join(ctx.pluginDir, filename)); os.IsNotExist(err) {
+		return exitWithError(fmt.Errorf("%w: %s n'est pas installé", errPluginNotFound, query))
+	}
+
+	msg := deleteFile(filename, ctx.pluginDir, false)().(operationCompleteMsg)
+	if msg.err != nil {
+		return exitWithError(fmt.Errorf("%s: %v", filename, msg.err))
+	}
+	if _, err := regenerateAliases(ctx.pluginDir); err != nil {
+		fmt.Printf("Attention: impossible de retirer l'alias pour %s: %v\n", filename, err)
+	}
+	if err := revokePermission(filename, ctx.baseDir); err != nil {
+		fmt.Printf("Attention: impossible de retirer les permissions de %s: %v\n", filename, err)
+	}
+	fmt.Printf("%s supprimé.\n", filename)
+	return exitOK
+}
+
+// Pannel update [plugin] : mettre à jour un plugin ou tous les plugins installés
+func cmdUpdate(ctx *cliContext, query string) int {
+	repos, repoErrors, err := loadRepos(ctx)
+	if err != nil {
+		return exitWithError(err)
+	}
+	warnRepoErrors(repoErrors)
+	state, err := loadInstalled(ctx.baseDir)
+	if err != nil {
+		return exitWithError(err)
+	}
+	localFiles := localPluginFiles(repos, ctx.pluginDir, state)
+
+	var candidates []repoPlugin
+	if query != "" {
+		p, err := findPlugin(repos, query)
+		if err != nil {
+			return exitWithError(err)
+		}
+		if !localFiles[localKey(p.repo.Name, p.file.Name)] {
+			return exitWithError(fmt.Errorf("%w: %s n'est pas installé", errPluginNotFound, p.file.Name))
+		}
+		candidates = append(candidates, p)
+	} else {
+		for _, repo := range repos {
+			for _, file := range repo.Files {
+				// localFiles ignore un même nom de fichier installé depuis un autre dépôt
+				if localFiles[localKey(repo.Name, file.Name)] {
+					candidates = append(candidates, repoPlugin{repo: repo, file: file})
+				}
+			}
+		}
+	}
+
+	updated, failed := 0, 0
+	for _, p := range candidates {
+		if pluginState(p.repo, p.file, true, state) != stateUpdatable {
+			if query != "" {
+				fmt.Printf("%s déjà à jour.\n", p.file.Name)
+			}
+			continue
+		}
+		if err := installPlugin(p, ctx.pluginDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Erreur: %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s mis à jour.\n", p.file.Name)
+		updated++
+	}
+
+	fmt.Printf("%d plugin(s) mis à jour, %d échec(s).\n", updated, failed)
+	if failed > 0 {
+		return exitError
+	}
+	return exitOK
+}
+
+// Pannel info <plugin>
+func cmdInfo(ctx *cliContext, query string, format string) int {
+	repos, repoErrors, err := loadRepos(ctx)
+	if err != nil {
+		return exitWithErrorFormat("info", format, err)
+	}
+	p, err := findPlugin(repos, query)
+	if err != nil {
+		return exitWithErrorFormat("info", format, err)
+	}
+
+	baseDir := ctx.baseDir
+	state, err := loadInstalled(baseDir)
+	if err != nil {
+		return exitWithErrorFormat("info", format, err)
+	}
+	local := localPluginFiles([]Repository{p.repo}, ctx.pluginDir, state)[localKey(p.repo.Name, p.file.Name)]
+	aliases, err := installedAliasNames(ctx.pluginDir)
+	if err != nil {
+		return exitWithErrorFormat("info", format, err)
+	}
+
+	// Manifeste : version installée, sinon version publiée
+	manifest, manifestErr := readLocalManifest(p.file.Name, ctx.pluginDir)
+	if manifest == nil && manifestErr == nil {
+		if remote, ok := p.repo.manifestFor(p.file); ok {
+			manifest, manifestErr = fetchManifest(remote)
+		}
+	}
+
+	var grant *permissionGrant
+	if perms, err := loadPermissions(baseDir); err == nil {
+		if g, ok := perms.Plugins[p.file.Name]; ok {
+			grant = &g
+		}
+	}
+
+	if format == outputJSON {
+		out := infoOutput(p, local, state, aliases, manifest, grant)
+		if manifestErr != nil {
+			out.Errors = append(out.Errors, jsonError{Code: "error", Message: manifestErr.Error()})
+		}
+		if err := writeJSON(os.Stdout, out); err != nil {
+			return exitWithError(err)
+		}
+		return exitOK
+	}
+
+	warnRepoErrors(repoErrors)
+	status := pluginState(p.repo, p.file, local, state)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Plugin:\t%s\n", p.file.Name)
+	fmt.Fprintf(w, "Dépôt:\t%s (%s)\n", p.repo.Name, trustLabels[repoTrust(p.repo)])
+	fmt.Fprintf(w, "URL:\t%s\n", p.file.DownloadURL)
+	fmt.Fprintf(w, "Taille:\t%d octets\n", p.file.Size)
+	fmt.Fprintf(w, "SHA distant:\t%s\n", p.file.SHA)
+	fmt.Fprintf(w, "État:\t%s\n", stateLabels[status])
+	if installed, ok := state.Plugins[p.file.Name]; ok && local {
+		fmt.Fprintf(w, "SHA installé:\t%s\n", installed.SHA)
+		fmt.Fprintf(w, "Installé le:\t%s\n", installed.InstalledAt.Local().Format(time.DateTime))
+	}
+	if alias := aliases[p.file.Name]; local && alias != "" {
+		fmt.Fprintf(w, "Alias:\t%s\n", alias)
+	}
+
+	if manifestErr != nil {
+		fmt.Fprintf(w, "Manifeste:\t%v\n", manifestErr)
+	} else if manifest != nil {
+		fmt.Fprintf(w, "Version:\t%s\n", manifest.Version)
+		fmt.Fprintf(w, "Description:\t%s\n", manifest.Description)
+		capabilities := manifest.Capabilities.describe()
+		if len(capabilities) == 0 {
+			capabilities = []string{"aucune"}
+		}
+		fmt.Fprintf(w, "Capacités:\t%s\n", strings.Join(capabilities, ", "))
+	}
+
+	if grant != nil {
+		fmt.Fprintf(w, "Autorisé le:\t%s par %s\n", grant.GrantedAt.Local().Format(time.DateTime), grant.GrantedBy)
+	}
+
+	if err := w.Flush(); err != nil {
+		return exitWithError(err)
+	}
+	return exitOK
+}
+
+// Pannel status : plugins installés et mises à jour disponibles
+func cmdStatus(ctx *cliContext, format string) int {
+	repos, repoErrors, err := loadRepos(ctx)
+	if err != nil {
+		return exitWithErrorFormat("status", format, err)
+	}
+	state, err := loadInstalled(ctx.baseDir)
+	if err != nil {
+		return exitWithErrorFormat("status", format, err)
+	}
+
+	aliases, err := installedAliasNames(ctx.pluginDir)
+	if err != nil {
+		return exitWithErrorFormat("status", format, err)
+	}
+
+	out := statusOutput(repos, localPluginFiles(repos, ctx.pluginDir, state), state, aliases, repoErrors)
+	if format == outputJSON {
+		if err := writeJSON(os.Stdout, out); err != nil {
+			return exitWithError(err)
+		}
+		return exitOK
+	}
+
+	warnRepoErrors(repoErrors)
+	if len(out.Plugins) == 0 {
+		fmt.Println("Aucun plugin installé.")
+		return exitOK
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PLUGIN\tDÉPÔT\tVERSION\tINSTALLÉ LE\tÉTAT")
+	for _, p := range out.Plugins {
+		version, installedAt := "?", "?"
+		if p.Installed != nil {
+			version = shortSHA(p.Installed.SHA)
+			installedAt = p.Installed.InstalledAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.Name, p.Repo, version, installedAt, stateLabels[p.State])
+	}
+	if err := w.Flush(); err != nil {
+		return exitWithError(err)
+	}
+	return exitOK
+}
+
+// Forme courte d'un SHA git
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	if sha == "" {
+		return "?"
+	}
+	return sha
+}
@@ -0,0 +1,695 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Nom de la commande dans l'aide et les scripts de complétion
+const programName = "Pannel"
+
+// Version de Pannel, fixée à la compilation : go build -ldflags "-X main.version=v1.2.0"
+var version = "dev"
+
+// Contexte partagé par toutes les commandes
+type cliContext struct {
+	baseDir    string // Dossier de Pannel (~/.Plugin par défaut)
+	pluginDir  string // baseDir/Plugin
+	configPath string // Configuration des dépôts (baseDir/repo.conf par défaut)
+	devPath    string // Plugin surveillé en mode développement ("" si inactif)
+	verbose    bool
+}
+
+// Détail des opérations sur la sortie d'erreur (--verbose)
+func (ctx *cliContext) logf(format string, args ...any) {
+	if ctx.verbose {
+		fmt.Fprintf(os.Stderr, "· "+format+"\n", args...)
+	}
+}
+
+// Options acceptées avant la commande comme après
+type globalOptions struct {
+	baseDir string
+	config  string
+	dev     string
+	noColor bool
+	verbose bool
+	version bool
+}
+
+// Déclarer les options communes à toutes les commandes
+func (o *globalOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.baseDir, "base-dir", o.baseDir, "Utiliser le `dossier` de Pannel indiqué (défaut ~/.Plugin)")
+	fs.StringVar(&o.baseDir, "c", o.baseDir, "Raccourci de --base-dir")
+	fs.StringVar(&o.config, "config", o.config, "Lire la configuration des dépôts dans ce `fichier` (défaut <base-dir>/repo.conf)")
+	fs.BoolVar(&o.noColor, "no-color", o.noColor, "Désactiver les couleurs")
+	fs.BoolVar(&o.verbose, "verbose", o.verbose, "Détailler les opérations sur la sortie d'erreur")
+}
+
+// Construire le contexte à partir des options
+func (o *globalOptions) context() (*cliContext, error) {
+	baseDir := o.baseDir
+	if baseDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("impossible de récupérer le dossier utilisateur: %v", err)
+		}
+		baseDir = filepath.Join(home, ".Plugin")
+	}
+	if abs, err := filepath.Abs(baseDir); err == nil {
+		baseDir = abs
+	}
+
+	// Une configuration indiquée explicitement doit exister (sinon repli silencieux sur le dépôt par défaut)
+	configPath := filepath.Join(baseDir, "repo.conf")
+	if o.config != "" {
+		if _, err := os.Stat(o.config); err != nil {
+			return nil, fmt.Errorf("configuration %s introuvable", o.config)
+		}
+		configPath = o.config
+	}
+
+	if o.noColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
+	ctx := &cliContext{
+		baseDir:    baseDir,
+		pluginDir:  filepath.Join(baseDir, "Plugin"),
+		configPath: configPath,
+		devPath:    o.dev,
+		verbose:    o.verbose,
+	}
+	ctx.logf("dossier: %s", ctx.baseDir)
+	return ctx, nil
+}
+
+// Exécution d'une commande, arguments déjà validés
+type runFunc func(ctx *cliContext, args []string) int
+
+// Commande de la ligne de commande
+type command struct {
+	name        string
+	args        string // Arguments attendus (aide)
+	summary     string
+	minArgs     int
+	maxArgs     int    // -1 = illimité
+	passthrough bool   // Options après le premier argument transmises telles quelles (run)
+	complete    string // Complétion des arguments : "installed", "shells", "themes" ou "commands"
+	hidden      bool   // Absente de l'aide (utilisée par les scripts de complétion)
+	setup       func(fs *flag.FlagSet) runFunc
+}
+
+// Commande sans option propre
+func noFlags(run runFunc) func(fs *flag.FlagSet) runFunc {
+	return func(fs *flag.FlagSet) runFunc { return run }
+}
+
+// Table des commandes (fonction plutôt que variable : help et completion s'y réfèrent)
+func commands() []command {
+	return []command{
+		{name: "install", summary: "Préparer le dossier de Pannel et charger les alias depuis le fichier de démarrage du shell",
+			setup: func(fs *flag.FlagSet) runFunc {
+				chargeur := fs.Bool("chargeur", false, "Télécharger aussi le script Chargeur")
+				shell := fs.String("shell", "", "`Shell` à configurer : bash, zsh ou fish (défaut : d'après $SHELL)")
+				dryRun := fs.Bool("dry-run", false, "Afficher la modification du fichier de démarrage sans rien écrire")
+				return func(ctx *cliContext, args []string) int { return cmdInstall(ctx, *chargeur, *shell, *dryRun) }
+			}},
+		{name: "uninstall", summary: "Supprimer les plugins, leurs données, les alias et les blocs des fichiers de démarrage",
+			setup: func(fs *flag.FlagSet) runFunc {
+				dryRun := fs.Bool("dry-run", false, "Afficher ce qui serait supprimé sans rien modifier")
+				return func(ctx *cliContext, args []string) int { return cmdUninstall(ctx, *dryRun) }
+			}},
+		{name: "list", summary: "Lister les plugins des dépôts",
+			setup: func(fs *flag.FlagSet) runFunc {
+				format := outputFlag(fs)
+				return func(ctx *cliContext, args []string) int { return cmdList(ctx, *format) }
+			}},
+		{name: "search", args: "<terme>", summary: "Chercher un plugin par nom", minArgs: 1, maxArgs: 1,
+			setup: noFlags(func(ctx *cliContext, args []string) int { return cmdSearch(ctx, args[0]) })},
+		{name: "info", args: "<plugin>", summary: "Détails d'un plugin : dépôt, version, manifeste, permissions", minArgs: 1, maxArgs: 1, complete: "installed",
+			setup: func(fs *flag.FlagSet) runFunc {
+				format := outputFlag(fs)
+				return func(ctx *cliContext, args []string) int { return cmdInfo(ctx, args[0], *format) }
+			}},
+		{name: "status", summary: "Plugins installés et mises à jour disponibles",
+			setup: func(fs *flag.FlagSet) runFunc {
+				format := outputFlag(fs)
+				return func(ctx *cliContext, args []string) int { return cmdStatus(ctx, *format) }
+			}},
+		{name: "add", args: "<plugin>", summary: "Installer un plugin", minArgs: 1, maxArgs: 1,
+			setup: noFlags(func(ctx *cliContext, args []string) int { return cmdAdd(ctx, args[0]) })},
+		{name: "remove", args: "<plugin>", summary: "Supprimer un plugin (ses données sont conservées)", minArgs: 1, maxArgs: 1, complete: "installed",
+			setup: noFlags(func(ctx *cliContext, args []string) int { return cmdRemove(ctx, args[0]) })},
+		{name: "update", args: "[plugin]", summary: "Mettre à jour un plugin ou tous les plugins installés", maxArgs: 1, complete: "installed",
+			setup: noFlags(func(ctx *cliContext, args []string) int {
+				query := ""
+				if len(args) > 0 {
+					query = args[0]
+				}
+				return cmdUpdate(ctx, query)
+			})},
+		{name: "run", args: "<plugin> [arguments...]", summary: "Lancer un plugin installé sans le panel", minArgs: 1, maxArgs: -1, passthrough: true, complete: "installed",
+			setup: noFlags(func(ctx *cliContext, args []string) int { return cmdRun(ctx, args[0], args[1:]) })},
+		{name: "aliases", summary: "Régénérer le fichier des alias depuis les plugins installés",
+			setup: func(fs *flag.FlagSet) runFunc {
+				dryRun := fs.Bool("dry-run", false, "Afficher le résultat sans modifier le fichier")
+				return func(ctx *cliContext, args []string) int { return cmdAliases(ctx, *dryRun) }
+			}},
+		{name: "theme", args: "[nom]", summary: "Afficher l'aperçu des thèmes de couleurs, ou d'un seul", maxArgs: 1, complete: "themes",
+			setup: noFlags(func(ctx *cliContext, args []string) int {
+				name := ""
+				if len(args) > 0 {
+					name = args[0]
+				}
+				return cmdTheme(ctx, name)
+			})},
+		{name: "doctor", summary: "Diagnostiquer l'installation et proposer des corrections",
+			setup: func(fs *flag.FlagSet) runFunc {
+				format := outputFlag(fs)
+				fix := fs.Bool("fix", false, "Appliquer les corrections automatiques")
+				return func(ctx *cliContext, args []string) int { return cmdDoctor(ctx, *format, *fix) }
+			}},
+		{name: "self-update", summary: "Mettre à jour Pannel et Chargeur depuis la dernière publication",
+			setup: func(fs *flag.FlagSet) runFunc {
+				check := fs.Bool("check", false, "Indiquer les mises à jour disponibles sans rien installer")
+				force := fs.Bool("force", false, "Installer la publication même si elle n'est pas plus récente")
+				source := fs.String("source", "", "`URL` de la publication (défaut : \"release\" de repo.conf, sinon GitHub)")
+				return func(ctx *cliContext, args []string) int { return cmdSelfUpdate(ctx, *source, *check, *force) }
+			}},
+		{name: "version", summary: "Afficher la version et les informations de compilation",
+			setup: noFlags(func(ctx *cliContext, args []string) int { return cmdVersion(os.Stdout) })},
+		{name: "completion", args: "<bash|zsh|fish>", summary: "Générer le script de complétion d'un shell", minArgs: 1, maxArgs: 1, complete: "shells",
+			setup: noFlags(func(ctx *cliContext, args []string) int { return cmdCompletion(os.Stdout, args[0]) })},
+		{name: "help", args: "[commande]", summary: "Afficher l'aide générale ou celle d'une commande", maxArgs: 1, complete: "commands",
+			setup: noFlags(func(ctx *cliContext, args []string) int { return cmdHelp(os.Stdout, args) })},
+		{name: "__plugins", summary: "Noms des plugins installés (complétion)", hidden: true,
+			setup: noFlags(func(ctx *cliContext, args []string) int { return cmdPluginNames(ctx) })},
+	}
+}
+
+// Trouver une commande par nom
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// Options d'une commande : les siennes puis les options globales
+func (c command) flagSet(opts *globalOptions) (*flag.FlagSet, runFunc) {
+	fs := flag.NewFlagSet(programName+" "+c.name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	run := c.setup(fs)
+	opts.register(fs)
+	return fs, run
+}
+
+// Analyser les options placées avant, entre ou après les arguments ("--" termine les options).
+// Une fois maxArgs arguments lus, le reste est renvoyé tel quel, options comprises.
+func parseArgs(fs *flag.FlagSet, args []string, maxArgs int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		// "--" consommé par fs.Parse : tout ce qui suit est un argument
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		if maxArgs >= 0 && len(positional) >= maxArgs {
+			return append(positional, rest[1:]...), nil
+		}
+		args = rest[1:]
+	}
+}
+
+// Point d'entrée : options globales, puis commande (sans commande : le panel)
+func runCLI(args []string) int {
+	opts := &globalOptions{}
+	global := flag.NewFlagSet(programName, flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	opts.register(global)
+	global.StringVar(&opts.dev, "dev", "", "Recharger à chaud ce plugin .so ou ce `dossier` source (mode développement)")
+	global.BoolVar(&opts.version, "version", false, "Afficher la version")
+
+	rest, err := parseArgs(global, args, 1)
+	if errors.Is(err, flag.ErrHelp) {
+		printUsage(os.Stdout)
+		return exitOK
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erreur: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Lancer \"%s help\" pour la liste des options.\n", programName)
+		return exitUsage
+	}
+	if opts.version {
+		return cmdVersion(os.Stdout)
+	}
+
+	if len(rest) == 0 {
+		ctx, err := opts.context()
+		if err != nil {
+			return exitWithError(err)
+		}
+		return runTUI(ctx)
+	}
+
+	cmd, ok := findCommand(rest[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Commande inconnue: %s\n", rest[0])
+		fmt.Fprintf(os.Stderr, "Lancer \"%s help\" pour la liste des commandes.\n", programName)
+		return exitUsage
+	}
+
+	fs, run := cmd.flagSet(opts)
+	maxArgs := -1
+	if cmd.passthrough {
+		maxArgs = cmd.minArgs
+	}
+	cmdArgs, err := parseArgs(fs, rest[1:], maxArgs)
+	if errors.Is(err, flag.ErrHelp) {
+		printCommandUsage(os.Stdout, cmd, fs)
+		return exitOK
+	}
+	if err == nil && (len(cmdArgs) < cmd.minArgs || (cmd.maxArgs >= 0 && len(cmdArgs) > cmd.maxArgs)) {
+		err = fmt.Errorf("nombre d'arguments invalide")
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erreur: %v\n\n", err)
+		printCommandUsage(os.Stderr, cmd, fs)
+		return exitUsage
+	}
+
+	ctx, err := opts.context()
+	if err != nil {
+		return exitWithError(err)
+	}
+	ctx.logf("commande: %s %s", cmd.name, strings.Join(cmdArgs, " "))
+	return run(ctx, cmdArgs)
+}
+
+// Nom d'une option tel qu'il s'écrit : -o, --output
+func flagName(name string) string {
+	if len(name) == 1 {
+		return "-" + name
+	}
+	return "--" + name
+}
+
+// Une option booléenne ne prend pas de valeur
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// Raccourcis d'options, affichés avec l'option complète dans l'aide
+var flagAliases = map[string]string{
+	"c": "base-dir",
+	"o": "output",
+}
+
+// Afficher les options d'un ensemble (ordre alphabétique)
+func printFlags(w io.Writer, fs *flag.FlagSet) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fs.VisitAll(func(f *flag.Flag) {
+		if _, ok := flagAliases[f.Name]; ok {
+			return
+		}
+		names := flagName(f.Name)
+		for alias, target := range flagAliases {
+			if target == f.Name && fs.Lookup(alias) != nil {
+				names = flagName(alias) + ", " + names
+			}
+		}
+		name, usage := flag.UnquoteUsage(f)
+		if name != "" {
+			name = " <" + name + ">"
+		}
+		fmt.Fprintf(tw, "  %s%s\t%s\n", names, name, usage)
+	})
+	tw.Flush()
+}
+
+// Aide générale
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage:\n  %s [options]                         Ouvrir le panel\n", programName)
+	fmt.Fprintf(w, "  %s [options] <commande> [arguments]\n\nCommandes:\n", programName)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands() {
+		if cmd.hidden {
+			continue
+		}
+		fmt.Fprintf(tw, "  %s %s\t%s\n", cmd.name, cmd.args, cmd.summary)
+	}
+	tw.Flush()
+
+	opts := &globalOptions{}
+	global := flag.NewFlagSet(programName, flag.ContinueOnError)
+	opts.register(global)
+	global.String("dev", "", "Recharger à chaud ce plugin .so ou ce `dossier` source (mode développement)")
+	global.Bool("version", false, "Afficher la version")
+	fmt.Fprintln(w, "\nOptions globales (avant ou après la commande) :")
+	printFlags(w, global)
+
+	fmt.Fprintf(w, "\nLancer \"%s help <commande>\" pour l'aide d'une commande.\n", programName)
+}
+
+// Aide d'une commande
+func printCommandUsage(w io.Writer, cmd command, fs *flag.FlagSet) {
+	usage := strings.TrimSpace(fmt.Sprintf("%s %s [options] %s", programName, cmd.name, cmd.args))
+	fmt.Fprintf(w, "Usage: %s\n\n%s\n\nOptions :\n", usage, cmd.summary)
+	printFlags(w, fs)
+}
+
+// Pannel help [commande]
+func cmdHelp(w io.Writer, args []string) int {
+	if len(args) == 0 {
+		printUsage(w)
+		return exitOK
+	}
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Commande inconnue: %s\n", args[0])
+		return exitUsage
+	}
+	fs, _ := cmd.flagSet(&globalOptions{})
+	printCommandUsage(w, cmd, fs)
+	return exitOK
+}
+
+// Pannel version : version, Go et dépendances liées aux plugins
+func cmdVersion(w io.Writer) int {
+	info, ok := debug.ReadBuildInfo()
+	current := version
+	if ok && current == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		// Installé avec "go install module@version"
+		current = info.Main.Version
+	}
+	fmt.Fprintf(w, "%s %s\n", programName, current)
+	if !ok {
+		return exitOK
+	}
+
+	settings := make(map[string]string)
+	for _, setting := range info.Settings {
+		settings[setting.Key] = setting.Value
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "  Go:\t%s %s/%s\n", info.GoVersion, runtime.GOOS, runtime.GOARCH)
+	if revision := settings["vcs.revision"]; revision != "" {
+		if settings["vcs.modified"] == "true" {
+			revision += " (modifié)"
+		}
+		fmt.Fprintf(tw, "  Commit:\t%s\n", revision)
+	}
+	if date := settings["vcs.time"]; date != "" {
+		fmt.Fprintf(tw, "  Date:\t%s\n", date)
+	}
+	// Les plugins doivent être compilés avec les mêmes versions
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/charmbracelet/bubbletea" || dep.Path == "github.com/charmbracelet/lipgloss" {
+			fmt.Fprintf(tw, "  %s:\t%s\n", path.Base(dep.Path), dep.Version)
+		}
+	}
+	tw.Flush()
+	return exitOK
+}
+
+// Pannel __plugins : noms des plugins installés, un par ligne
+func cmdPluginNames(ctx *cliContext) int {
+	files, err := installedPluginFiles(ctx.pluginDir)
+	if err != nil {
+		return exitError
+	}
+	for _, file := range files {
+		fmt.Println(pluginName(file))
+	}
+	return exitOK
+}
+
+// Shells pris en charge par "Pannel completion"
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// Pannel completion <shell>
+func cmdCompletion(w io.Writer, shell string) int {
+	switch shell {
+	case "bash":
+		fmt.Fprint(w, bashCompletion())
+	case "zsh":
+		fmt.Fprintf(w, "#compdef %s\n# Complétion zsh de %s (générée par \"%s completion zsh\")\n", programName, programName, programName)
+		fmt.Fprint(w, "autoload -U +X bashcompinit && bashcompinit\n\n")
+		fmt.Fprint(w, bashCompletion())
+	case "fish":
+		fmt.Fprint(w, fishCompletion())
+	default:
+		fmt.Fprintf(os.Stderr, "Erreur: shell inconnu: %s (%s)\n", shell, strings.Join(completionShells, ", "))
+		return exitUsage
+	}
+	return exitOK
+}
+
+// Options globales de complétion (--dev et --version seulement avant la commande)
+func completionGlobalFlags() *flag.FlagSet {
+	global := flag.NewFlagSet(programName, flag.ContinueOnError)
+	(&globalOptions{}).register(global)
+	global.String("dev", "", "Plugin .so ou dossier source à recharger à chaud")
+	global.Bool("version", false, "Afficher la version")
+	global.Bool("help", false, "Afficher l'aide")
+	return global
+}
+
+// Valeurs proposées pour une option : "dirs", "files" ou une liste de mots
+func flagValueCompletion(name string) string {
+	switch name {
+	case "base-dir", "c":
+		return "dirs"
+	case "output", "o":
+		return "text json"
+	case "shell":
+		return strings.Join(shellNames(), " ")
+	}
+	return "files"
+}
+
+// Toutes les options d'un ensemble, triées
+func flagNames(fs *flag.FlagSet, values bool) []string {
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		if !values || !isBoolFlag(f) {
+			names = append(names, flagName(f.Name))
+		}
+	})
+	sort.Strings(names)
+	return names
+}
+
+// Mots complétés pour les arguments d'une commande (bash)
+func bashArgCompletion(kind string, names []string) string {
+	switch kind {
+	case "installed":
+		return `$(` + programName + ` "${base[@]}" __plugins 2>/dev/null)`
+	case "shells":
+		return strings.Join(completionShells, " ")
+	case "themes":
+		return strings.Join(builtinThemeNames(), " ")
+	case "commands":
+		return strings.Join(names, " ")
+	}
+	return ""
+}
+
+// Script de complétion bash, généré depuis la table des commandes
+func bashCompletion() string {
+	var names []string
+	for _, cmd := range commands() {
+		if !cmd.hidden {
+			names = append(names, cmd.name)
+		}
+	}
+
+	// Options attendant une valeur, regroupées par type de valeur
+	global := completionGlobalFlags()
+	valueFlags := make(map[string][]string)
+	addValueFlags := func(fs *flag.FlagSet) {
+		fs.VisitAll(func(f *flag.Flag) {
+			if isBoolFlag(f) {
+				return
+			}
+			kind := flagValueCompletion(f.Name)
+			for _, name := range valueFlags[kind] {
+				if name == flagName(f.Name) {
+					return
+				}
+			}
+			valueFlags[kind] = append(valueFlags[kind], flagName(f.Name))
+		})
+	}
+	addValueFlags(global)
+	for _, cmd := range commands() {
+		fs, _ := cmd.flagSet(&globalOptions{})
+		addValueFlags(fs)
+	}
+	var kinds, allValueFlags []string
+	for kind, flags := range valueFlags {
+		kinds = append(kinds, kind)
+		allValueFlags = append(allValueFlags, flags...)
+	}
+	sort.Strings(kinds)
+	sort.Strings(allValueFlags)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Complétion bash de %s (générée par \"%s completion bash\")\n", programName, programName)
+	fmt.Fprintf(&b, "_pannel() {\n")
+	fmt.Fprintf(&b, "    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(&b, "    local cmd=\"\" opts=\"\" base=() i\n\n")
+	fmt.Fprintf(&b, "    # Commande déjà saisie et dossier de Pannel utilisé\n")
+	fmt.Fprintf(&b, "    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	fmt.Fprintf(&b, "        case \"${COMP_WORDS[i]}\" in\n")
+	fmt.Fprintf(&b, "            --base-dir|-c) base=(--base-dir \"${COMP_WORDS[i+1]}\"); ((i++)) ;;\n")
+	fmt.Fprintf(&b, "            %s) ((i++)) ;;\n", strings.Join(allValueFlags, "|"))
+	fmt.Fprintf(&b, "            -*) ;;\n")
+	fmt.Fprintf(&b, "            *) [[ -z \"$cmd\" ]] && cmd=\"${COMP_WORDS[i]}\" ;;\n")
+	fmt.Fprintf(&b, "        esac\n    done\n\n")
+
+	fmt.Fprintf(&b, "    case \"$prev\" in\n")
+	for _, kind := range kinds {
+		reply := `$(compgen -W "` + kind + `" -- "$cur")`
+		switch kind {
+		case "dirs":
+			reply = `$(compgen -d -- "$cur")`
+		case "files":
+			reply = `$(compgen -f -- "$cur")`
+		}
+		fmt.Fprintf(&b, "        %s) COMPREPLY=(%s); return ;;\n", strings.Join(valueFlags[kind], "|"), reply)
+	}
+	fmt.Fprintf(&b, "    esac\n\n")
+
+	fmt.Fprintf(&b, "    if [[ -z \"$cmd\" ]]; then\n")
+	fmt.Fprintf(&b, "        if [[ \"$cur\" == -* ]]; then\n")
+	fmt.Fprintf(&b, "            COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(flagNames(global, false), " "))
+	fmt.Fprintf(&b, "        else\n")
+	fmt.Fprintf(&b, "            COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(names, " "))
+	fmt.Fprintf(&b, "        fi\n        return\n    fi\n\n")
+
+	fmt.Fprintf(&b, "    case \"$cmd\" in\n")
+	for _, cmd := range commands() {
+		if cmd.hidden {
+			continue
+		}
+		fs, _ := cmd.flagSet(&globalOptions{})
+		fs.Bool("help", false, "")
+		fmt.Fprintf(&b, "        %s) opts=\"%s\" ;;\n", cmd.name, strings.Join(flagNames(fs, false), " "))
+	}
+	fmt.Fprintf(&b, "    esac\n")
+	fmt.Fprintf(&b, "    if [[ \"$cur\" == -* ]]; then\n")
+	fmt.Fprintf(&b, "        COMPREPLY=($(compgen -W \"$opts\" -- \"$cur\"))\n        return\n    fi\n\n")
+
+	fmt.Fprintf(&b, "    case \"$cmd\" in\n")
+	for _, cmd := range commands() {
+		if words := bashArgCompletion(cmd.complete, names); words != "" {
+			fmt.Fprintf(&b, "        %s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) ;;\n", cmd.name, words)
+		}
+	}
+	fmt.Fprintf(&b, "    esac\n}\n")
+	fmt.Fprintf(&b, "complete -F _pannel %s\n", programName)
+	return b.String()
+}
+
+// Chaîne entre apostrophes pour fish
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// Ligne "complete" fish pour une option
+func fishFlag(condition string, f *flag.Flag) string {
+	line := "complete -c " + programName
+	if condition != "" {
+		line += " -n " + fishQuote(condition)
+	}
+	if len(f.Name) == 1 {
+		line += " -s " + f.Name
+	} else {
+		line += " -l " + f.Name
+	}
+	if !isBoolFlag(f) {
+		switch values := flagValueCompletion(f.Name); values {
+		case "dirs":
+			line += " -x -a '(__fish_complete_directories)'"
+		case "files":
+			line += " -r -F"
+		default:
+			line += " -x -a " + fishQuote(values)
+		}
+	}
+	_, usage := flag.UnquoteUsage(f)
+	return line + " -d " + fishQuote(usage) + "\n"
+}
+
+// Script de complétion fish, généré depuis la table des commandes
+func fishCompletion() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Complétion fish de %s (générée par \"%s completion fish\")\n", programName, programName)
+	fmt.Fprintf(&b, "complete -c %s -f\n\n", programName)
+
+	global := completionGlobalFlags()
+	global.VisitAll(func(f *flag.Flag) {
+		b.WriteString(fishFlag("", f))
+	})
+	b.WriteString("\n")
+
+	var names []string
+	for _, cmd := range commands() {
+		if cmd.hidden {
+			continue
+		}
+		names = append(names, cmd.name)
+		fmt.Fprintf(&b, "complete -c %s -n __fish_use_subcommand -a %s -d %s\n", programName, cmd.name, fishQuote(cmd.summary))
+	}
+
+	for _, cmd := range commands() {
+		if cmd.hidden {
+			continue
+		}
+		condition := "__fish_seen_subcommand_from " + cmd.name
+		fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		cmd.setup(fs)
+		fs.VisitAll(func(f *flag.Flag) {
+			b.WriteString(fishFlag(condition, f))
+		})
+
+		switch cmd.complete {
+		case "installed":
+			fmt.Fprintf(&b, "complete -c %s -n %s -a '(%s __plugins 2>/dev/null)'\n", programName, fishQuote(condition), programName)
+		case "shells":
+			fmt.Fprintf(&b, "complete -c %s -n %s -a %s\n", programName, fishQuote(condition), fishQuote(strings.Join(completionShells, " ")))
+		case "themes":
+			fmt.Fprintf(&b, "complete -c %s -n %s -a %s\n", programName, fishQuote(condition), fishQuote(strings.Join(builtinThemeNames(), " ")))
+		case "commands":
+			fmt.Fprintf(&b, "complete -c %s -n %s -a %s\n", programName, fishQuote(condition), fishQuote(strings.Join(names, " ")))
+		}
+	}
+	return b.String()
+}
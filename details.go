@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Délai avant de charger les détails du plugin sous le curseur : parcourir la liste ne lance aucun téléchargement
+const detailsDelay = 150 * time.Millisecond
+
+// Entrées du changelog affichées dans le panneau de détails
+const detailsChangelogMax = 5
+
+// Détails lents à obtenir (manifeste, alias), chargés en arrière-plan
+type pluginDetails struct {
+	manifest  *pluginManifest
+	published bool   // Manifeste publié par le dépôt (pas de manifeste installé)
+	alias     string // Alias généré ("" si aucun)
+	aliasNote string // Conflit de nom de l'alias
+	err       error
+}
+
+type detailsTickMsg struct {
+	key string
+}
+
+type detailsLoadedMsg struct {
+	key     string
+	details pluginDetails
+}
+
+// Clé d'un plugin dans le cache des détails
+func detailsKey(repo Repository, file GitHubFile) string {
+	return repo.Name + "/" + file.Name
+}
+
+// Plugin sous le curseur du panel 1 (ok = false sur un en-tête de dépôt)
+func (m model) currentPlugin() (Repository, GitHubFile, bool) {
+	if m.cursor >= len(m.displayLines) || m.displayLines[m.cursor].isHeader {
+		return Repository{}, GitHubFile{}, false
+	}
+	line := m.displayLines[m.cursor]
+	repo := m.repos[line.repoIdx]
+	return repo, repo.Files[line.fileIdx], true
+}
+
+// Oublier les détails chargés (plugins installés, supprimés ou alias renommé)
+func (m *model) resetDetails() {
+	m.details = make(map[string]pluginDetails)
+	m.detailsLoading = make(map[string]bool)
+}
+
+// Programmer le chargement des détails du plugin sous le curseur
+func (m model) scheduleDetails() tea.Cmd {
+	repo, file, ok := m.currentPlugin()
+	if !ok {
+		return nil
+	}
+	key := detailsKey(repo, file)
+	if _, ok := m.details[key]; ok || m.detailsLoading[key] {
+		return nil
+	}
+	return tea.Tick(detailsDelay, func(time.Time) tea.Msg {
+		return detailsTickMsg{key: key}
+	})
+}
+
+// Fin du délai : charger les détails si le curseur est resté sur le plugin
+func (m model) handleDetailsTick(msg detailsTickMsg) (tea.Model, tea.Cmd) {
+	repo, file, ok := m.currentPlugin()
+	if !ok || detailsKey(repo, file) != msg.key || m.detailsLoading[msg.key] {
+		return m, nil
+	}
+	if _, ok := m.details[msg.key]; ok {
+		return m, nil
+	}
+	m.detailsLoading[msg.key] = true
+	return m, loadDetails(msg.key, repo, file, m.localFiles[localKey(repo.Name, file.Name)], m.pluginDir)
+}
+
+func (m model) handleDetailsLoaded(msg detailsLoadedMsg) (tea.Model, tea.Cmd) {
+	// Chargement lancé avant resetDetails : résultat périmé
+	if !m.detailsLoading[msg.key] {
+		return m, nil
+	}
+	delete(m.detailsLoading, msg.key)
+	m.details[msg.key] = msg.details
+	return m, nil
+}
+
+// Lire le manifeste (installé, sinon publié) et l'alias d'un plugin
+func loadDetails(key string, repo Repository, file GitHubFile, local bool, pluginDir string) tea.Cmd {
+	return func() tea.Msg {
+		var details pluginDetails
+		if local {
+			details.manifest, details.err = readLocalManifest(file.Name, pluginDir)
+
+			if files, err := installedPluginFiles(pluginDir); err == nil {
+				names, conflicts, err := resolveAliasNames(files, filepath.Dir(pluginDir))
+				if err == nil {
+					details.alias = names[file.Name]
+					for _, conflict := range conflicts {
+						if conflict.filename == file.Name {
+							details.aliasNote = conflict.String()
+						}
+					}
+				}
+			}
+		}
+		if details.manifest == nil && details.err == nil {
+			if remote, ok := repo.manifestFor(file); ok {
+				details.manifest, details.err = fetchManifest(remote)
+				details.published = true
+			}
+		}
+		return detailsLoadedMsg{key: key, details: details}
+	}
+}
+
+// Taille lisible d'un fichier
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f Mo", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f Ko", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d octets", size)
+}
+
+// Panneau de droite du panel 1 : détails du plugin sous le curseur, limités à la hauteur du panneau
+func (m model) detailsView(repo Repository, file GitHubFile, width int, height int) string {
+	labelStyle := m.theme.style().Foreground(m.theme.Muted)
+	titleStyle := m.theme.style().Bold(true).Foreground(m.theme.Accent)
+	wrap := lipgloss.NewStyle().Width(max(width-4, 10))
+
+	var lines []string
+	field := func(label string, value string) {
+		lines = append(lines, "  "+labelStyle.Render(fmt.Sprintf("%-12s", label))+" "+value)
+	}
+	paragraph := func(text string) {
+		for _, line := range strings.Split(wrap.Render(text), "\n") {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	local := m.localFiles[localKey(repo.Name, file.Name)]
+	lines = append(lines, "", "  "+titleStyle.Render(pluginName(file.Name)), "")
+	field("Dépôt", fmt.Sprintf("%s (%s)", repo.Name, trustLabels[repoTrust(repo)]))
+	if local {
+		field("Chemin", filepath.Join(m.pluginDir, file.Name))
+	} else {
+		field("Chemin", "non installé")
+	}
+	field("Taille", formatSize(file.Size))
+	field("SHA distant", shortSHA(file.SHA))
+	state := pluginState(repo, file, local, m.installed)
+	if state == stateForeign {
+		field("État", fmt.Sprintf("%s (%s)", stateLabels[state], m.installed.Plugins[file.Name].Repo))
+	} else {
+		field("État", stateLabels[state])
+	}
+	if installed, ok := m.installed.Plugins[file.Name]; ok && local {
+		field("Installé", fmt.Sprintf("%s le %s", shortSHA(installed.SHA), installed.InstalledAt.Local().Format(time.DateTime)))
+	}
+
+	details, loaded := m.details[detailsKey(repo, file)]
+	if loaded && local {
+		switch {
+		case details.alias != "":
+			field("Alias", details.alias)
+		case details.aliasNote == "":
+			field("Alias", "aucun")
+		}
+		if details.aliasNote != "" {
+			paragraph("⚠️ " + details.aliasNote)
+		}
+	}
+
+	switch {
+	case !loaded:
+		lines = append(lines, "", "  ⏳ Chargement du manifeste...")
+	case details.err != nil:
+		lines = append(lines, "")
+		paragraph(fmt.Sprintf("⚠️ Manifeste: %v", details.err))
+	default:
+		if details.manifest == nil {
+			lines = append(lines, "", "  Aucun manifeste publié")
+			break
+		}
+
+		manifest := details.manifest
+		version := manifest.Version
+		if details.published && local {
+			version += " (publiée)"
+		}
+		field("Version", version)
+		if manifest.Description != "" {
+			lines = append(lines, "")
+			paragraph(manifest.Description)
+		}
+
+		capabilities := manifest.Capabilities.describe()
+		if len(capabilities) == 0 {
+			capabilities = []string{"aucune"}
+		}
+		lines = append(lines, "", "  "+labelStyle.Render("Capacités"))
+		for _, capability := range capabilities {
+			paragraph("• " + capability)
+		}
+
+		if len(manifest.Changelog) > 0 {
+			lines = append(lines, "", "  "+labelStyle.Render("Changelog"))
+			for i, entry := range manifest.Changelog {
+				if i == detailsChangelogMax {
+					lines = append(lines, fmt.Sprintf("  … %d entrée(s) plus ancienne(s)", len(manifest.Changelog)-i))
+					break
+				}
+				paragraph("• " + entry)
+			}
+		}
+	}
+
+	if height > 1 && len(lines) > height {
+		lines = append(lines[:height-1], "  …")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Intervalle de surveillance du mode développement
+const devPollInterval = 500 * time.Millisecond
+
+// Surveillance d'un plugin en développement (fichier .so ou dossier source)
+type devWatcher struct {
+	path    string    // Chemin surveillé
+	isDir   bool      // true = dossier source à compiler, false = fichier .so
+	outDir  string    // Dossier des copies uniques (baseDir/dev)
+	lastMod time.Time // Date de la dernière version chargée
+	pending time.Time // Modification vue au tick précédent (attente de stabilité)
+	busy    bool      // Compilation/copie en cours
+	build   int       // Numéro du dernier build
+	current string    // Copie actuellement chargée
+}
+
+// Message pour le tick de surveillance
+type devTickMsg time.Time
+
+// Message de fin de compilation/copie d'un plugin de développement
+type devBuildMsg struct {
+	filename string // Nom de la copie unique dans outDir
+	build    int
+	output   string // Sortie de go build en cas d'erreur
+	err      error
+}
+
+// Créer la surveillance d'un plugin en développement
+func newDevWatcher(path string, baseDir string) (*devWatcher, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, fmt.Errorf("chemin de développement introuvable: %v", err)
+	}
+
+	// Repartir d'un dossier de copies vide à chaque lancement
+	outDir := filepath.Join(baseDir, "dev")
+	if err := os.RemoveAll(outDir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &devWatcher{path: abs, isDir: info.IsDir(), outDir: outDir}, nil
+}
+
+// Nom affiché du plugin surveillé
+func (w *devWatcher) name() string {
+	base := filepath.Base(w.path)
+	if w.isDir {
+		return base + ".so"
+	}
+	return base
+}
+
+// Date de modification la plus récente des fichiers surveillés
+func (w *devWatcher) latestMod() (time.Time, error) {
+	if !w.isDir {
+		info, err := os.Stat(w.path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return info.ModTime(), nil
+	}
+
+	var latest time.Time
+	err := filepath.WalkDir(w.path, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != w.path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ext := filepath.Ext(path); ext != ".go" && d.Name() != "go.mod" && d.Name() != "go.sum" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest, err
+}
+
+// Commande pour le tick de surveillance
+func devTickCmd() tea.Cmd {
+	return tea.Tick(devPollInterval, func(t time.Time) tea.Msg {
+		return devTickMsg(t)
+	})
+}
+
+// Commande pour produire une copie unique du plugin (compilation si dossier source)
+func buildDevPlugin(w *devWatcher, build int) tea.Cmd {
+	path, isDir, outDir := w.path, w.isDir, w.outDir
+	name := strings.TrimSuffix(w.name(), ".so")
+
+	return func() tea.Msg {
+		// Go refuse de rouvrir un plugin sous le même chemin : chaque build a son propre fichier
+		filename := fmt.Sprintf("%s-%d-%d.so", name, build, time.Now().Unix())
+		dest := filepath.Join(outDir, filename)
+
+		if isDir {
+			// pluginpath unique, sinon plugin.Open répond "plugin already loaded"
+			pluginPath := fmt.Sprintf("-ldflags=-pluginpath=gotui-dev/%s-%d-%d", name, build, time.Now().UnixNano())
+			cmd := exec.Command("go", "build", "-buildmode=plugin", pluginPath, "-o", dest, ".")
+			cmd.Dir = path
+			output, err := cmd.CombinedOutput()
+			if err != nil {
+				return devBuildMsg{filename: filename, build: build, output: string(output), err: fmt.Errorf("échec de la compilation: %v", err)}
+			}
+			return devBuildMsg{filename: filename, build: build}
+		}
+
+		src, err := os.Open(path)
+		if err != nil {
+			return devBuildMsg{filename: filename, build: build, err: err}
+		}
+		defer src.Close()
+
+		out, err := os.Create(dest)
+		if err != nil {
+			return devBuildMsg{filename: filename, build: build, err: err}
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, src); err != nil {
+			return devBuildMsg{filename: filename, build: build, err: err}
+		}
+		return devBuildMsg{filename: filename, build: build}
+	}
+}
+
+// Charger une copie de développement (les erreurs ne ferment pas le plugin courant)
+func loadDevPlugin(w *devWatcher, filename string) tea.Cmd {
+	// Les données sont rangées sous le nom du plugin, pas sous celui de la copie
+	pluginPath := filepath.Join(w.outDir, filename)
+	name, baseDir := pluginName(w.name()), filepath.Dir(w.outDir)
+	return func() tea.Msg {
+		msg := loadPluginFile(pluginPath, name, baseDir, nil)
+		msg.dev = true
+		msg.filename = filename
+		return msg
+	}
+}
+
+// Vérifier si le plugin surveillé a changé
+func (m model) handleDevTick() (tea.Model, tea.Cmd) {
+	w := m.dev
+	if w.busy {
+		return m, devTickCmd()
+	}
+
+	mod, err := w.latestMod()
+	if err != nil {
+		// Fichier en cours de réécriture ou supprimé : réessayer au prochain tick
+		return m, devTickCmd()
+	}
+
+	if !mod.After(w.lastMod) {
+		return m, devTickCmd()
+	}
+
+	// Attendre un tick sans modification avant de recharger (écriture terminée)
+	if !mod.Equal(w.pending) {
+		w.pending = mod
+		return m, devTickCmd()
+	}
+
+	w.lastMod = mod
+	w.busy = true
+	w.build++
+	if w.isDir {
+		m.addLog(fmt.Sprintf("🔨 Compilation de %s (build #%d)", w.name(), w.build))
+	} else {
+		m.addLog(fmt.Sprintf("🔄 Nouvelle version de %s détectée (build #%d)", w.name(), w.build))
+	}
+	return m, tea.Batch(buildDevPlugin(w, w.build), devTickCmd())
+}
+
+// Charger le résultat d'un build de développement
+func (m model) handleDevBuild(msg devBuildMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.dev.busy = false
+		m.addLog(fmt.Sprintf("❌ Build #%d de %s: %v", msg.build, m.dev.name(), msg.err))
+		for _, line := range strings.Split(strings.TrimSpace(msg.output), "\n") {
+			if line != "" {
+				m.addLog("   " + line)
+			}
+		}
+		return m, nil
+	}
+	return m, loadDevPlugin(m.dev, msg.filename)
+}
+
+// Remplacer le plugin affiché par la nouvelle version en conservant le focus
+func (m model) handleDevPluginLoaded(msg pluginLoadedMsg) (tea.Model, tea.Cmd) {
+	w := m.dev
+	w.busy = false
+
+	if msg.err != nil {
+		m.addLog(fmt.Sprintf("❌ Rechargement de %s: %v", w.name(), msg.err))
+		if strings.Contains(msg.err.Error(), "already loaded") {
+			m.addLog("   Compilez avec -ldflags=-pluginpath=<nom unique> pour chaque build")
+		} else if strings.Contains(msg.err.Error(), "different version") {
+			m.addLog("   Plugin compilé avec une version de Go ou de dépendances différente de Pannel")
+		}
+		os.Remove(filepath.Join(w.outDir, msg.filename))
+		return m, nil
+	}
+
+	// Le plugin précédent reste en mémoire, mais sa copie n'est plus utile
+	if w.current != "" {
+		os.Remove(filepath.Join(w.outDir, w.current))
+	}
+	w.current = msg.filename
+
+	m.embeddedTUI = msg.model
+	m.embeddedPluginID = ""
+	m.runningTUI = fmt.Sprintf("%s [dev #%d]", w.name(), w.build)
+	m.addLog(fmt.Sprintf("✅ %s rechargé", m.runningTUI))
+
+	initCmd := m.embeddedTUI.Init()
+	var cmd tea.Cmd
+	m.embeddedTUI, cmd = m.embeddedTUI.Update(m.pluginWindowSize())
+	return m, tea.Batch(initCmd, cmd)
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Boîte de dialogue modale affichée au-dessus des panels
+type dialog struct {
+	title   string
+	lines   []string
+	options []dialogOption
+	input   *dialogInput // Champ de saisie (nil si aucun)
+	list    *dialogList  // Éléments à cocher (nil si aucun)
+}
+
+// Nombre maximal de lignes visibles de la liste à cocher
+const dialogListHeight = 10
+
+// Bordure et marges d'une boîte de dialogue
+const (
+	dialogChromeWidth  = 6
+	dialogChromeHeight = 4
+)
+
+// Éléments à cocher d'une boîte de dialogue : touches up/down pour naviguer, toggle pour cocher
+type dialogList struct {
+	items  []dialogListItem
+	cursor int
+}
+
+type dialogListItem struct {
+	group   string // Regroupement du résumé ("à installer"...)
+	label   string
+	checked bool
+}
+
+// Résumé des éléments cochés par groupe, dans l'ordre de la liste : "2 à installer, 1 à supprimer"
+func (l *dialogList) summary() string {
+	var groups []string
+	counts := make(map[string]int)
+	for _, item := range l.items {
+		if _, ok := counts[item.group]; !ok {
+			groups = append(groups, item.group)
+			counts[item.group] = 0
+		}
+		if item.checked {
+			counts[item.group]++
+		}
+	}
+	parts := make([]string, len(groups))
+	for i, group := range groups {
+		parts[i] = fmt.Sprintf("%d %s", counts[group], group)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Position des éléments cochés
+func (l *dialogList) checkedIndexes() []int {
+	var indexes []int
+	for i, item := range l.items {
+		if item.checked {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// Appliquer l'action d'une touche (raccourcis du panel des plugins) ; false si elle ne concerne pas la liste
+func (l *dialogList) handleKey(action string) bool {
+	switch action {
+	case actUp:
+		l.cursor = max(0, l.cursor-1)
+	case actDown:
+		l.cursor = min(len(l.items)-1, l.cursor+1)
+	case actToggle:
+		l.items[l.cursor].checked = !l.items[l.cursor].checked
+	default:
+		return false
+	}
+	return true
+}
+
+// Rendre la liste sur width colonnes, rows éléments visibles
+func (l *dialogList) render(t theme, width int, rows int) string {
+	keyStyle := t.style().Foreground(t.Accent)
+	start := max(0, min(l.cursor-rows/2, len(l.items)-rows))
+	end := min(len(l.items), start+rows)
+
+	var b strings.Builder
+	b.WriteString(truncateText(t.style().Bold(true).Render(l.summary()), width) + "\n\n")
+	if start > 0 {
+		b.WriteString(fmt.Sprintf("  ▲ %d de plus\n", start))
+	}
+	for i := start; i < end; i++ {
+		item := l.items[i]
+		cursor := "  "
+		if i == l.cursor {
+			cursor = keyStyle.Render("▸ ")
+		}
+		check := "[ ]"
+		if item.checked {
+			check = keyStyle.Render("[x]")
+		}
+		b.WriteString(cursor + check + " " + truncateText(item.label, width-6) + "\n")
+	}
+	if end < len(l.items) {
+		b.WriteString(fmt.Sprintf("  ▼ %d de plus\n", len(l.items)-end))
+	}
+	return b.String()
+}
+
+// Champ de saisie d'une boîte de dialogue, validé par Entrée
+type dialogInput struct {
+	value  string
+	submit func(m model, value string) (tea.Model, tea.Cmd)
+}
+
+// Choix d'une boîte de dialogue
+type dialogOption struct {
+	keys   []string // Touches associées (celles du keymap pour confirmer ou annuler)
+	label  string
+	action func(m model) (tea.Model, tea.Cmd)
+}
+
+// Fermer la boîte de dialogue sans rien faire
+func closeDialog(m model) (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+// Choix de confirmation : touches de "confirm", comme la validation de la sélection
+func (m model) confirmOption(label string, action func(m model) (tea.Model, tea.Cmd)) dialogOption {
+	return dialogOption{keys: m.keys.binding(actConfirm).keys, label: label, action: action}
+}
+
+// Choix d'annulation : touches de "clear_search" (Échap par défaut), sans effet
+func (m model) cancelOption() dialogOption {
+	return dialogOption{keys: m.keys.binding(actClearSearch).keys, label: "Annuler", action: closeDialog}
+}
+
+// Transmettre une touche à la boîte de dialogue (les autres touches sont ignorées)
+func (d *dialog) handleKey(m model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if d.input != nil {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.dialog = nil
+			return d.input.submit(m, d.input.value)
+		case tea.KeyBackspace:
+			if runes := []rune(d.input.value); len(runes) > 0 {
+				d.input.value = string(runes[:len(runes)-1])
+			}
+			return m, nil
+		case tea.KeyRunes, tea.KeySpace:
+			d.input.value += string(msg.Runes)
+			return m, nil
+		}
+	}
+	if d.list != nil && d.list.handleKey(m.keys.action(1, msg)) {
+		return m, nil
+	}
+	for _, option := range d.options {
+		if slices.Contains(option.keys, msg.String()) {
+			m.dialog = nil
+			return option.action(m)
+		}
+	}
+	return m, nil
+}
+
+// Rendre la boîte pour une vue de width x height : texte replié à la largeur, liste réduite en hauteur.
+// Si elle reste trop haute, les marges verticales et les lignes d'explication sont retirées.
+func (d *dialog) render(t theme, width int, height int) string {
+	box := d.renderBox(t, width, height, false)
+	if lipgloss.Height(box) > height {
+		box = d.renderBox(t, width, height, true)
+	}
+	return box
+}
+
+func (d *dialog) renderBox(t theme, width int, height int, compact bool) string {
+	titleStyle := t.style().Bold(true)
+	keyStyle := t.style().Foreground(t.Accent)
+	inner := max(width-dialogChromeWidth, 1)
+	chromeHeight, padding := dialogChromeHeight, 1
+	if compact {
+		chromeHeight, padding = dialogChromeHeight-2, 0
+	}
+
+	var head strings.Builder
+	head.WriteString(ansi.Wrap(titleStyle.Render(d.title), inner, "") + "\n")
+	if !compact {
+		head.WriteString("\n")
+		for _, line := range d.lines {
+			head.WriteString(ansi.Wrap(line, inner, "") + "\n")
+		}
+	}
+
+	var foot strings.Builder
+	if d.input != nil {
+		// Fin de la saisie visible
+		value := d.input.value + "█"
+		if w := ansi.StringWidth(value); w > inner-2 {
+			value = ansi.TruncateLeft(value, w-(inner-2), "")
+		}
+		foot.WriteString("\n" + keyStyle.Render(">") + " " + value + "\n")
+	}
+
+	var options []string
+	if d.input != nil {
+		options = append(options, keyStyle.Render("[Entrée]")+" Valider")
+	}
+	for _, option := range d.options {
+		options = append(options, keyStyle.Render("["+keysLabel(option.keys)+"]")+" "+option.label)
+	}
+	foot.WriteString("\n" + ansi.Wrap(strings.Join(options, "   "), inner, ""))
+
+	content := head.String()
+	if d.list != nil {
+		// Résumé, ligne vide et marques ▲/▼ autour des éléments
+		used := lipgloss.Height(head.String()) - 1 + lipgloss.Height(foot.String()) + chromeHeight + 4
+		content += d.list.render(t, inner, max(1, min(dialogListHeight, height-used)))
+	}
+	content += foot.String()
+
+	return t.style().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Accent).
+		Padding(padding, 2).
+		Render(content)
+}
+
+// Superposer un bloc au centre d'une vue déjà rendue. Un bloc plus grand que la vue est coupé
+// à sa largeur, et en hauteur avant sa dernière ligne (bordure du bas gardée).
+func overlayCenter(background string, foreground string, width int, height int) string {
+	bgLines := strings.Split(background, "\n")
+	for len(bgLines) < height {
+		bgLines = append(bgLines, "")
+	}
+
+	fgLines := strings.Split(foreground, "\n")
+	if n := len(fgLines); height > 1 && n > height {
+		fgLines = append(fgLines[:height-1], fgLines[n-1])
+	}
+	if width > 0 && lipgloss.Width(foreground) > width {
+		for i, line := range fgLines {
+			fgLines[i] = ansi.Truncate(line, width, "")
+		}
+	}
+	fgWidth := 0
+	for _, line := range fgLines {
+		fgWidth = max(fgWidth, ansi.StringWidth(line))
+	}
+	x := max(0, (width-fgWidth)/2)
+	y := max(0, (height-len(fgLines))/2)
+
+	for i, fgLine := range fgLines {
+		row := y + i
+		if row >= len(bgLines) {
+			break
+		}
+		bg := bgLines[row]
+
+		left := ansi.Truncate(bg, x, "")
+		if w := ansi.StringWidth(left); w < x {
+			left += strings.Repeat(" ", x-w)
+		}
+		right := ansi.TruncateLeft(bg, x+ansi.StringWidth(fgLine), "")
+
+		bgLines[row] = left + fgLine + right
+	}
+
+	return strings.Join(bgLines, "\n")
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Résultat d'une vérification
+const (
+	checkOK   = "ok"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// Symboles des résultats pour l'affichage
+var checkSymbols = map[string]string{
+	checkOK:   "✅",
+	checkWarn: "⚠️",
+	checkFail: "❌",
+}
+
+// Point vérifié par Pannel doctor
+type doctorCheck struct {
+	name    string       // Identifiant stable (sortie JSON)
+	title   string       // Libellé affiché
+	status  string       // checkOK, checkWarn ou checkFail
+	message string       // Constat
+	fix     string       // Action conseillée ("" si rien à faire)
+	apply   func() error // Correction automatique (nil si manuelle)
+	fixed   bool         // Corrigé par --fix
+}
+
+// URL du quota de l'API GitHub (la consulter ne le consomme pas)
+const rateLimitURL = "https://api.github.com/rate_limit"
+
+// Vérifications dans l'ordre : les corrections des premières servent aux suivantes
+var doctorChecks = []func(ctx *cliContext) []doctorCheck{
+	checkLayout,
+	checkChargeur,
+	checkAliasFiles,
+	checkRcBlocks,
+	checkAliases,
+	checkAliasNames,
+	checkKeymap,
+	checkTheme,
+	checkRepos,
+	checkRateLimit,
+	checkABI,
+}
+
+// Dossiers de Pannel
+func checkLayout(ctx *cliContext) []doctorCheck {
+	c := doctorCheck{name: "layout", title: "Dossiers", status: checkOK, message: ctx.pluginDir + " présent"}
+	if info, err := os.Stat(ctx.pluginDir); err != nil || !info.IsDir() {
+		c.status = checkFail
+		c.message = ctx.pluginDir + " absent"
+		c.fix = "Créer le dossier (Pannel install)"
+		c.apply = func() error { return os.MkdirAll(ctx.pluginDir, 0755) }
+	}
+	return []doctorCheck{c}
+}
+
+// Script Chargeur (optionnel, doit être exécutable s'il existe)
+func checkChargeur(ctx *cliContext) []doctorCheck {
+	chargeurFile := filepath.Join(ctx.baseDir, "Chargeur")
+	c := doctorCheck{name: "chargeur", title: "Chargeur", status: checkOK}
+
+	info, err := os.Stat(chargeurFile)
+	switch {
+	case os.IsNotExist(err):
+		c.message = "absent : les alias utilisent « Pannel run »"
+	case err != nil:
+		c.status = checkFail
+		c.message = err.Error()
+	case info.IsDir():
+		c.status = checkFail
+		c.message = chargeurFile + " est un dossier"
+		c.fix = "Supprimer " + chargeurFile
+	case info.Mode()&0111 == 0:
+		c.status = checkFail
+		c.message = chargeurFile + " n'est pas exécutable"
+		c.fix = "chmod +x " + chargeurFile
+		c.apply = func() error { return os.Chmod(chargeurFile, 0755) }
+	default:
+		c.message = chargeurFile + " exécutable"
+	}
+	return []doctorCheck{c}
+}
+
+// Fichier des alias de chaque shell configuré
+func checkAliasFiles(ctx *cliContext) []doctorCheck {
+	var checks []doctorCheck
+	for _, shell := range activeShells(ctx.baseDir) {
+		shell := shell
+		aliasFile := shell.aliasPath(ctx.baseDir)
+		c := doctorCheck{name: "alias_file", title: "Alias " + shell.name, status: checkOK, message: aliasFile + " présent"}
+		if _, err := os.Stat(aliasFile); err != nil {
+			c.status = checkFail
+			c.message = aliasFile + " absent"
+			c.fix = "Créer le fichier des alias (Pannel install --shell " + shell.name + ")"
+			c.apply = func() error { return shell.initAliasFile(ctx.baseDir) }
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// Bloc de chargement des alias dans le fichier rc de chaque shell configuré
+func checkRcBlocks(ctx *cliContext) []doctorCheck {
+	var checks []doctorCheck
+	for _, shell := range activeShells(ctx.baseDir) {
+		c := doctorCheck{name: "rc_block", title: "Démarrage " + shell.name, status: checkOK}
+		rcPath, err := shell.rcPath()
+		if err != nil {
+			c.status = checkFail
+			c.message = err.Error()
+			checks = append(checks, c)
+			continue
+		}
+
+		aliasFile := shell.aliasPath(ctx.baseDir)
+		block := shell.sourceBlock(aliasFile)
+		edit, err := planRcBlock(rcPath, block, true)
+		switch {
+		case err != nil:
+			c.status = checkFail
+			c.message = err.Error()
+		case !edit.changed():
+			c.message = "bloc de chargement présent dans " + rcPath
+		case strings.Contains(edit.current, block):
+			c.status = checkWarn
+			c.message = "bloc de chargement sans bornes (ancienne version) dans " + rcPath
+			c.fix = "Encadrer le bloc (Pannel install --shell " + shell.name + ")"
+		default:
+			c.status = checkFail
+			c.message = "bloc de chargement de " + aliasFile + " absent de " + rcPath
+			c.fix = "Ajouter le bloc (Pannel install --shell " + shell.name + ")"
+		}
+		if edit.changed() {
+			c.apply = func() error {
+				_, err := edit.apply()
+				return err
+			}
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// Alias cohérents avec les plugins installés
+func checkAliases(ctx *cliContext) []doctorCheck {
+	var checks []doctorCheck
+	for _, shell := range activeShells(ctx.baseDir) {
+		c := doctorCheck{name: "aliases", title: "Alias " + shell.name, status: checkOK}
+		if _, err := os.Stat(shell.aliasPath(ctx.baseDir)); err != nil {
+			// Déjà signalé par checkAliasFiles
+			continue
+		}
+
+		plan, err := planAliases(shell, ctx.pluginDir)
+		switch {
+		case err != nil:
+			c.status = checkFail
+			c.message = err.Error()
+		case !plan.changed():
+			c.message = "alias à jour"
+		default:
+			c.status = checkWarn
+			c.message = "alias à régénérer (" + plan.summary() + ")"
+			c.fix = "Pannel aliases"
+			c.apply = plan.apply
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// Noms d'alias sans conflit avec les commandes du système, du shell et des autres plugins
+func checkAliasNames(ctx *cliContext) []doctorCheck {
+	conflicts, err := aliasConflicts(ctx.pluginDir)
+	if err != nil {
+		return []doctorCheck{{name: "alias_names", title: "Noms d'alias", status: checkFail, message: err.Error()}}
+	}
+	if len(conflicts) == 0 {
+		return []doctorCheck{{name: "alias_names", title: "Noms d'alias", status: checkOK, message: "aucun conflit"}}
+	}
+
+	var checks []doctorCheck
+	for _, conflict := range conflicts {
+		c := doctorCheck{name: "alias_names", title: "Noms d'alias", status: checkWarn, message: conflict.String()}
+		if conflict.refused {
+			c.fix = fmt.Sprintf("Choisir un autre nom pour %s (touche n du panel ou %s)", conflict.filename, aliasOverridesPath(ctx.baseDir))
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// Raccourcis clavier redéfinis dans repo.conf
+func checkKeymap(ctx *cliContext) []doctorCheck {
+	_, warnings := loadKeymap(ctx.configPath)
+	if len(warnings) == 0 {
+		return []doctorCheck{{name: "keys", title: "Raccourcis", status: checkOK, message: "aucun conflit"}}
+	}
+
+	var checks []doctorCheck
+	for _, warning := range warnings {
+		checks = append(checks, doctorCheck{name: "keys", title: "Raccourcis", status: checkWarn, message: warning,
+			fix: fmt.Sprintf("Corriger la section \"keys\" de %s", ctx.configPath)})
+	}
+	return checks
+}
+
+// Thème de repo.conf
+func checkTheme(ctx *cliContext) []doctorCheck {
+	t, warnings := configTheme(ctx.configPath)
+	if len(warnings) == 0 {
+		return []doctorCheck{{name: "theme", title: "Thème", status: checkOK, message: t.name}}
+	}
+
+	var checks []doctorCheck
+	for _, warning := range warnings {
+		checks = append(checks, doctorCheck{name: "theme", title: "Thème", status: checkWarn, message: warning,
+			fix: fmt.Sprintf("Corriger \"theme\" ou la section \"themes\" de %s", ctx.configPath)})
+	}
+	return checks
+}
+
+// Dépôts joignables
+func checkRepos(ctx *cliContext) []doctorCheck {
+	repos, repoErrors, err := loadRepos(ctx)
+	if err != nil {
+		return []doctorCheck{{name: "repos", title: "Dépôts", status: checkFail, message: err.Error(),
+			fix: "Vérifier la connexion et les URL de " + ctx.configPath}}
+	}
+
+	checks := []doctorCheck{{name: "repos", title: "Dépôts", status: checkOK,
+		message: fmt.Sprintf("%d dépôt(s) joignable(s)", len(repos))}}
+	for _, err := range repoErrors {
+		checks = append(checks, doctorCheck{name: "repos", title: "Dépôts", status: checkWarn, message: err.Error(),
+			fix: "Vérifier l'URL du dépôt dans " + ctx.configPath})
+	}
+	for _, repo := range repos {
+		if repo.KeyError != nil {
+			checks = append(checks, doctorCheck{name: "repos", title: "Dépôts", status: checkFail,
+				message: fmt.Sprintf("%s bloqué: %v", repo.Name, repo.KeyError),
+				fix:     fmt.Sprintf("Corriger public_key de %s dans %s", repo.Name, ctx.configPath)})
+		}
+	}
+	return checks
+}
+
+// Quota de l'API GitHub (60 requêtes par heure sans authentification)
+func checkRateLimit(ctx *cliContext) []doctorCheck {
+	c := doctorCheck{name: "rate_limit", title: "Quota GitHub", status: checkOK}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(rateLimitURL)
+	if err != nil {
+		c.status = checkWarn
+		c.message = fmt.Sprintf("impossible de vérifier: %v", err)
+		return []doctorCheck{c}
+	}
+	defer resp.Body.Close()
+
+	var limits struct {
+		Resources struct {
+			Core struct {
+				Limit     int   `json:"limit"`
+				Remaining int   `json:"remaining"`
+				Reset     int64 `json:"reset"`
+			} `json:"core"`
+		} `json:"resources"`
+	}
+	if resp.StatusCode != 200 {
+		c.status = checkWarn
+		c.message = fmt.Sprintf("HTTP %d pour %s", resp.StatusCode, rateLimitURL)
+		return []doctorCheck{c}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&limits); err != nil {
+		c.status = checkWarn
+		c.message = fmt.Sprintf("réponse illisible: %v", err)
+		return []doctorCheck{c}
+	}
+
+	core := limits.Resources.Core
+	reset := time.Unix(core.Reset, 0).Local().Format("15:04")
+	c.message = fmt.Sprintf("%d/%d requêtes restantes", core.Remaining, core.Limit)
+	switch {
+	case core.Remaining == 0:
+		c.status = checkFail
+		c.message = "quota épuisé, les dépôts ne peuvent pas être listés"
+		c.fix = "Attendre la réinitialisation à " + reset
+	case core.Remaining < 10:
+		c.status = checkWarn
+		c.fix = "Quota bientôt épuisé, réinitialisé à " + reset
+	}
+	return []doctorCheck{c}
+}
+
+// Plugins installés compilés avec la même version de Go et des mêmes dépendances
+func checkABI(ctx *cliContext) []doctorCheck {
+	files, err := installedPluginFiles(ctx.pluginDir)
+	if err != nil || len(files) == 0 {
+		return nil
+	}
+
+	var checks []doctorCheck
+	for _, file := range files {
+		mismatches, err := checkPluginABI(filepath.Join(ctx.pluginDir, file))
+		if err != nil {
+			checks = append(checks, doctorCheck{name: "abi", title: "ABI", status: checkWarn,
+				message: fmt.Sprintf("%s: %v", file, err)})
+			continue
+		}
+		if len(mismatches) > 0 {
+			checks = append(checks, doctorCheck{name: "abi", title: "ABI", status: checkFail,
+				message: fmt.Sprintf("%s compilé avec %s", file, strings.Join(mismatches, ", ")),
+				fix:     fmt.Sprintf("Pannel update %s, ou recompiler avec %s et les dépendances de « Pannel version »", pluginName(file), runtime.Version())})
+		}
+	}
+
+	if len(checks) == 0 {
+		checks = append(checks, doctorCheck{name: "abi", title: "ABI", status: checkOK,
+			message: fmt.Sprintf("%d plugin(s) compatible(s)", len(files))})
+	}
+	return checks
+}
+
+// Lancer les vérifications, en corrigeant au fur et à mesure si demandé
+func runDoctorChecks(ctx *cliContext, fix bool) []doctorCheck {
+	var results []doctorCheck
+	for _, check := range doctorChecks {
+		for _, c := range check(ctx) {
+			if fix && c.apply != nil && c.status != checkOK {
+				if err := c.apply(); err != nil {
+					c.message += fmt.Sprintf(" (correction impossible: %v)", err)
+				} else {
+					c.fixed = true
+				}
+			}
+			ctx.logf("%s: %s", c.name, c.status)
+			results = append(results, c)
+		}
+	}
+	return results
+}
+
+// Pannel doctor [--fix] : diagnostiquer l'installation
+func cmdDoctor(ctx *cliContext, format string, fix bool) int {
+	checks := runDoctorChecks(ctx, fix)
+
+	code := exitOK
+	for _, c := range checks {
+		if c.status == checkFail && !c.fixed {
+			code = exitError
+		}
+	}
+
+	if format == outputJSON {
+		if err := writeJSON(os.Stdout, doctorOutput(checks)); err != nil {
+			return exitWithError(err)
+		}
+		return code
+	}
+
+	// Colonnes alignées à la main : tabwriter compte mal la largeur des emojis
+	fixable := 0
+	for _, c := range checks {
+		symbol := checkSymbols[c.status]
+		if c.fixed {
+			symbol = "🔧"
+		}
+		fmt.Printf("%s %-14s %s\n", symbol, c.title, c.message)
+		switch {
+		case c.fixed:
+			fmt.Printf("%18s→ corrigé : %s\n", "", c.fix)
+		case c.fix != "":
+			fmt.Printf("%18s→ %s\n", "", c.fix)
+		}
+		if c.apply != nil && !c.fixed {
+			fixable++
+		}
+	}
+
+	if fixable > 0 && !fix {
+		fmt.Printf("\n%d problème(s) corrigeable(s) automatiquement : Pannel doctor --fix\n", fixable)
+	}
+	return code
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Écran d'aide ("?") : raccourcis de chaque panel, générés depuis les raccourcis actifs
+type helpScreen struct {
+	plugin     string      // Plugin en cours d'exécution ("" si aucun)
+	pluginKeys []pluginKey // Raccourcis déclarés dans son manifeste
+	offset     int         // Première ligne affichée (aide plus haute que le terminal)
+}
+
+// Section de l'aide : titre et lignes "touche, description"
+type helpSection struct {
+	title string
+	rows  [][2]string
+}
+
+// Ouvrir l'aide, avec les raccourcis du plugin en cours d'exécution s'il en déclare
+func (m *model) openHelp() {
+	m.help = &helpScreen{plugin: m.runningTUI}
+	if m.embeddedTUI == nil || m.runningTUI == "" {
+		return
+	}
+	manifest, err := readLocalManifest(m.runningTUI, m.pluginDir)
+	if err != nil {
+		m.addLog(fmt.Sprintf("⚠️ Aide de %s: %v", m.runningTUI, err))
+		return
+	}
+	if manifest != nil {
+		m.help.pluginKeys = manifest.Keys
+	}
+}
+
+// Sections de l'aide : raccourcis globaux, de chaque panel, de la recherche et du plugin
+func (m model) helpSections() []helpSection {
+	row := func(action string) [2]string {
+		return [2]string{m.keys.keyLabel(action), m.keys.binding(action).help}
+	}
+
+	global := helpSection{title: "Partout"}
+	for _, b := range m.keys.bindings {
+		if b.panels == nil {
+			global.rows = append(global.rows, row(b.action))
+		}
+	}
+	global.rows = append(global.rows, [2]string{quitKey, "Quitter (toujours)"})
+
+	sections := []helpSection{global}
+	for _, panel := range []struct {
+		id    int
+		title string
+	}{{1, "Plugins (1)"}, {2, "Logs (2)"}} {
+		section := helpSection{title: panel.title}
+		for _, b := range m.keys.panelBindings(panel.id) {
+			section.rows = append(section.rows, row(b.action))
+		}
+		sections = append(sections, section)
+	}
+
+	sections = append(sections,
+		helpSection{title: "Recherche", rows: [][2]string{
+			{"Enter", "Garder le filtre"},
+			{"Échap", "Effacer le filtre"},
+		}},
+		helpSection{title: "Boîtes de dialogue", rows: [][2]string{
+			{m.keys.keyLabel(actUp) + " " + m.keys.keyLabel(actDown), "Naviguer dans la liste"},
+			{m.keys.keyLabel(actToggle), "Cocher/décocher"},
+			{"Échap", "Annuler"},
+		}},
+	)
+
+	if m.help != nil && m.help.plugin != "" {
+		section := helpSection{title: "Plugin " + pluginName(m.help.plugin) + " (3)"}
+		for _, key := range m.help.pluginKeys {
+			section.rows = append(section.rows, [2]string{key.Key, key.Help})
+		}
+		if len(section.rows) == 0 {
+			section.rows = append(section.rows, [2]string{"", "aucun raccourci déclaré dans son manifeste"})
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// Bordure et marges de l'aide : colonnes et lignes autour du contenu, titre et pied compris
+const (
+	helpChromeWidth  = 6
+	helpChromeHeight = 8
+)
+
+// Contenu de l'aide pour une fenêtre de width x height : sections en colonnes, le moins de lignes possible
+// sans dépasser la largeur. Retourne les lignes et la hauteur visible (défilement si elles sont plus nombreuses).
+func (m model) helpBody(width int, height int) ([]string, int) {
+	sectionStyle := m.theme.style().Bold(true).Foreground(m.theme.Accent)
+	keyStyle := m.theme.style().Foreground(m.theme.Accent)
+	innerWidth := max(width-helpChromeWidth, 1)
+	available := max(height-helpChromeHeight, 1)
+
+	var blocks []string
+	total := 0
+	for _, section := range m.helpSections() {
+		keyWidth := 0
+		for _, r := range section.rows {
+			keyWidth = max(keyWidth, lipgloss.Width(r[0]))
+		}
+		lines := []string{truncateText(sectionStyle.Render(section.title), innerWidth)}
+		for _, r := range section.rows {
+			key := r[0] + strings.Repeat(" ", keyWidth-lipgloss.Width(r[0]))
+			lines = append(lines, truncateText("  "+keyStyle.Render(key)+"  "+r[1], innerWidth))
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+		total += len(lines) + 1
+	}
+
+	// Colonnes remplies jusqu'à la hauteur limite, agrandie tant qu'elles dépassent la largeur
+	columns := helpColumns(blocks, available)
+	for limit := available + 1; helpColumnsWidth(columns) > innerWidth && limit <= total; limit++ {
+		columns = helpColumns(blocks, limit)
+	}
+
+	rendered := make([]string, len(columns))
+	for i, column := range columns {
+		rendered[i] = strings.Join(column, "\n\n")
+		if i < len(columns)-1 {
+			rendered[i] = lipgloss.NewStyle().PaddingRight(helpColumnGap).Render(rendered[i])
+		}
+	}
+	return strings.Split(lipgloss.JoinHorizontal(lipgloss.Top, rendered...), "\n"), available
+}
+
+// Espace entre deux colonnes de l'aide
+const helpColumnGap = 4
+
+// Sections empilées en colonnes d'au plus limit lignes (une section n'est pas coupée)
+func helpColumns(blocks []string, limit int) [][]string {
+	var columns [][]string
+	var column []string
+	height := 0
+	for _, block := range blocks {
+		h := lipgloss.Height(block) + 1
+		if height > 0 && height+h > limit {
+			columns = append(columns, column)
+			column, height = nil, 0
+		}
+		column = append(column, block)
+		height += h
+	}
+	return append(columns, column)
+}
+
+// Largeur des colonnes côte à côte
+func helpColumnsWidth(columns [][]string) int {
+	width := helpColumnGap * (len(columns) - 1)
+	for _, column := range columns {
+		w := 0
+		for _, block := range column {
+			w = max(w, lipgloss.Width(block))
+		}
+		width += w
+	}
+	return width
+}
+
+// Défiler l'aide d'un nombre de lignes, sans dépasser la fin
+func (m *model) scrollHelp(delta int) {
+	lines, available := m.helpBody(m.width, m.height)
+	m.help.offset = max(0, min(m.help.offset+delta, len(lines)-available))
+}
+
+// Rendre l'aide dans une fenêtre de width x height, avec ▲/▼ si elle ne tient pas en hauteur
+func (m model) renderHelp(width int, height int) string {
+	titleStyle := m.theme.style().Bold(true)
+	keyStyle := m.theme.style().Foreground(m.theme.Accent)
+	innerWidth := max(width-helpChromeWidth, 1)
+
+	lines, available := m.helpBody(width, height)
+	footer := keyStyle.Render("["+m.keys.keyLabel(actHelp)+"]") + " ou " + keyStyle.Render("[Esc]") + " Fermer"
+	if len(lines) > available {
+		offset := max(0, min(m.help.offset, len(lines)-available))
+		end := offset + available
+		visible := slices.Clone(lines[offset:end])
+		if offset > 0 {
+			visible[0] = fmt.Sprintf("▲ %d de plus", offset)
+		}
+		if end < len(lines) {
+			visible[len(visible)-1] = fmt.Sprintf("▼ %d de plus", len(lines)-end)
+		}
+		lines = visible
+		footer += "   " + keyStyle.Render("["+m.keys.keyLabel(actUp)+" "+m.keys.keyLabel(actDown)+"]") + " Défiler"
+	}
+
+	content := truncateText(titleStyle.Render("Aide"), innerWidth) + "\n\n" +
+		strings.Join(lines, "\n") + "\n\n" +
+		truncateText(footer, innerWidth)
+
+	return m.theme.style().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.Accent).
+		Padding(1, 2).
+		Render(content)
+}
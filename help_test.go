@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func helpModel(width int, height int) model {
+	t, _ := builtinTheme("dark")
+	return model{keys: defaultKeymap(), theme: t, width: width, height: height, help: &helpScreen{}}
+}
+
+// L'aide tient dans le terminal, pied (touche de fermeture) compris
+func TestRenderHelpSize(t *testing.T) {
+	for _, size := range []struct{ width, height int }{
+		{80, 24}, {120, 40}, {60, 20}, {40, 12}, {30, 10},
+	} {
+		m := helpModel(size.width, size.height)
+		help := m.renderHelp(size.width, size.height)
+		if w, h := lipgloss.Width(help), lipgloss.Height(help); w > size.width || h > size.height {
+			t.Errorf("aide à %dx%d : %dx%d", size.width, size.height, w, h)
+		}
+		if size.width >= 60 && !strings.Contains(ansi.Strip(help), "Fermer") {
+			t.Errorf("aide à %dx%d sans pied:\n%s", size.width, size.height, ansi.Strip(help))
+		}
+
+		background := strings.TrimSuffix(strings.Repeat(strings.Repeat(".", size.width)+"\n", size.height), "\n")
+		view := overlayCenter(background, help, size.width, size.height)
+		lines := strings.Split(view, "\n")
+		if len(lines) != size.height {
+			t.Errorf("vue à %dx%d : %d lignes", size.width, size.height, len(lines))
+		}
+		for i, line := range lines {
+			if w := ansi.StringWidth(line); w != size.width {
+				t.Errorf("vue à %dx%d : ligne %d de %d colonnes", size.width, size.height, i, w)
+			}
+		}
+	}
+}
+
+// Aide plus haute que le terminal : marques ▲/▼ et défilement jusqu'à la fin
+func TestRenderHelpScroll(t *testing.T) {
+	m := helpModel(80, 24)
+	m.help.plugin = "Journal.so"
+	for i := range 30 {
+		m.help.pluginKeys = append(m.help.pluginKeys, pluginKey{Key: fmt.Sprintf("f%d", i+1), Help: "Action du plugin"})
+	}
+
+	help := ansi.Strip(m.renderHelp(80, 24))
+	if lipgloss.Height(help) > 24 || !strings.Contains(help, "▼") || strings.Contains(help, "▲") {
+		t.Errorf("début de l'aide:\n%s", help)
+	}
+	if !strings.Contains(help, "Défiler") {
+		t.Errorf("touches de défilement absentes:\n%s", help)
+	}
+
+	for range 100 {
+		m.scrollHelp(1)
+	}
+	help = ansi.Strip(m.renderHelp(80, 24))
+	if !strings.Contains(help, "▲") || strings.Contains(help, "▼") || !strings.Contains(help, "f30") {
+		t.Errorf("fin de l'aide:\n%s", help)
+	}
+
+	m.scrollHelp(-1000)
+	if m.help.offset != 0 {
+		t.Errorf("décalage %d après retour au début", m.help.offset)
+	}
+}
+
+// Un bloc plus grand que la vue est coupé sans dépasser, bordure du bas gardée
+func TestOverlayCenterClip(t *testing.T) {
+	fg := lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Render(strings.Repeat(strings.Repeat("x", 30)+"\n", 9) + strings.Repeat("x", 30))
+	view := overlayCenter("", fg, 20, 6)
+	lines := strings.Split(view, "\n")
+	if len(lines) != 6 {
+		t.Fatalf("%d lignes, attendu 6:\n%s", len(lines), view)
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w > 20 {
+			t.Errorf("ligne %d de %d colonnes", i, w)
+		}
+	}
+	if !strings.HasPrefix(lines[5], "└") {
+		t.Errorf("bordure du bas absente: %q", lines[5])
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Pannel install [--chargeur] [--shell bash|zsh|fish] [--dry-run] : préparer les dossiers et le chargement des alias
+func cmdInstall(ctx *cliContext, withChargeur bool, shellName string, dryRun bool) int {
+	pluginDir := ctx.pluginDir
+	chargeurFile := filepath.Join(ctx.baseDir, "Chargeur")
+
+	shell := detectShell()
+	if shellName != "" {
+		var err error
+		if shell, err = findShell(shellName); err != nil {
+			fmt.Fprintf(os.Stderr, "Erreur: %v\n", err)
+			return exitUsage
+		}
+	}
+	rcPath, err := shell.rcPath()
+	if err != nil {
+		return exitWithError(err)
+	}
+	aliasFile := shell.aliasPath(ctx.baseDir)
+	ctx.logf("shell: %s (%s)", shell.name, rcPath)
+
+	edit, err := planRcBlock(rcPath, shell.sourceBlock(aliasFile), true)
+	if err != nil {
+		return exitWithError(err)
+	}
+	if dryRun {
+		printRcEdit(edit)
+		return exitOK
+	}
+
+	// --- Créer le dossier ./.Plugin/Plugin ---
+	if _, err := os.Stat(pluginDir); os.IsNotExist(err) {
+		err := os.MkdirAll(pluginDir, 0755)
+		if err != nil {
+			fmt.Printf("Erreur création du dossier %s: %s", pluginDir, err)
+			return exitError
+		}
+		fmt.Printf("Dossier %s créé.\n", pluginDir)
+	}
+
+	// --- Télecharge le fichier ./.Plugin/Chargeur (optionnel, "Pannel run" le remplace) ---
+	if _, err := os.Stat(chargeurFile); os.IsNotExist(err) && withChargeur {
+		DownloadchargeurFile := GitHubFile{
+			Name:        "Chargeur",
+			Type:        "file",
+			DownloadURL: "https://raw.githubusercontent.com/TWilhem/Plugin/main/Chargeur",
+		}
+
+		cmd := downloadFile(DownloadchargeurFile, ctx.baseDir, nil)
+
+		msg := cmd()
+		if opMsg, ok := msg.(operationCompleteMsg); ok {
+			if opMsg.err != nil {
+				fmt.Printf("Erreur téléchargement Chargeur: %v\n", opMsg.err)
+				return exitError
+			}
+			os.Chmod(chargeurFile, 0755)
+			fmt.Printf("Fichier Chargeur téléchargé avec succès.\n")
+		}
+	}
+
+	// --- Créer le fichier des alias du shell (./.Plugin/.pluginbashrc, .pluginzshrc ou .plugin.fish) ---
+	if _, err := os.Stat(aliasFile); os.IsNotExist(err) {
+		if err := shell.initAliasFile(ctx.baseDir); err != nil {
+			fmt.Printf("Erreur création du fichier %s: %s\n", aliasFile, err)
+			return exitError
+		}
+		fmt.Printf("Fichier %s créé.\n", aliasFile)
+	} else {
+		fmt.Printf("Fichier %s déjà existant.\n", aliasFile)
+	}
+
+	// --- Alias des plugins déjà installés ---
+	if plan, err := planAliases(shell, pluginDir); err != nil || plan.apply() != nil {
+		fmt.Printf("Attention: impossible de générer les alias dans %s\n", aliasFile)
+	}
+
+	// --- Ajouter le bloc dans le fichier de démarrage du shell (sauvegardé avant modification) ---
+	if !edit.changed() {
+		fmt.Printf("Bloc plugin déjà présent dans %s\n", rcPath)
+	} else {
+		backup, err := edit.apply()
+		if err != nil {
+			fmt.Printf("Erreur écriture dans %s: %s\n", edit.path, err)
+			return exitError
+		}
+		fmt.Printf("Bloc plugin ajouté à %s%s\n", edit.path, backupNote(backup))
+	}
+	fmt.Printf("Recharger le shell pour utiliser les alias : source %s\n", rcPath)
+	return exitOK
+}
+
+// Afficher la modification prévue d'un fichier rc (--dry-run)
+func printRcEdit(edit rcEdit) {
+	if !edit.changed() {
+		fmt.Printf("%s : aucune modification\n", edit.path)
+		return
+	}
+	fmt.Print(edit.diff())
+}
+
+// Mention de la sauvegarde dans les messages
+func backupNote(backup string) string {
+	if backup == "" {
+		return ""
+	}
+	return " (sauvegarde : " + backup + ")"
+}
+
+// Pannel uninstall [--dry-run] : retirer les alias (tous shells), les plugins et l'état local
+func cmdUninstall(ctx *cliContext, dryRun bool) int {
+	// --- Retirer le bloc de chargement du fichier de démarrage de chaque shell ---
+	for _, shell := range shells {
+		rcPath, err := shell.rcPath()
+		if err != nil {
+			return exitWithError(err)
+		}
+		edit, err := planRcBlock(rcPath, shell.sourceBlock(shell.aliasPath(ctx.baseDir)), false)
+		if err != nil {
+			fmt.Printf("Erreur lecture %s: %s\n", rcPath, err)
+			continue
+		}
+		if !edit.changed() {
+			continue
+		}
+		if dryRun {
+			printRcEdit(edit)
+			continue
+		}
+		backup, err := edit.apply()
+		if err != nil {
+			fmt.Printf("Erreur écriture %s: %s\n", edit.path, err)
+			continue
+		}
+		fmt.Printf("Bloc plugin supprimé de %s%s\n", edit.path, backupNote(backup))
+	}
+
+	// --- Supprimer les fichiers d'alias, les plugins, leurs données, l'état local et Chargeur ---
+	var paths []string
+	for _, shell := range shells {
+		paths = append(paths, shell.aliasPath(ctx.baseDir))
+	}
+	paths = append(paths,
+		ctx.pluginDir,
+		permissionsPath(ctx.baseDir),
+		installedPath(ctx.baseDir),
+		aliasOverridesPath(ctx.baseDir),
+		filepath.Join(ctx.baseDir, "data"),
+		filepath.Join(ctx.baseDir, "Chargeur"),
+	)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		kind := "Fichier"
+		if err == nil && info.IsDir() {
+			kind = "Répertoire"
+		}
+		if dryRun {
+			fmt.Printf("%s %s à supprimer.\n", kind, path)
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			fmt.Printf("Erreur suppression %s: %s\n", path, err)
+			continue
+		}
+		fmt.Printf("%s %s supprimé.\n", kind, path)
+	}
+	return exitOK
+}
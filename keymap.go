@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Actions du clavier (noms utilisés dans la section "keys" de repo.conf)
+const (
+	actQuit           = "quit"
+	actHelp           = "help"
+	actNextPanel      = "next_panel"
+	actPanelHome      = "panel_home"
+	actPanelRepos     = "panel_repos"
+	actPanelLogs      = "panel_logs"
+	actFullScreen     = "fullscreen"
+	actUp             = "up"
+	actDown           = "down"
+	actTop            = "top"
+	actBottom         = "bottom"
+	actToggle         = "toggle"
+	actConfirm        = "confirm"
+	actInstall        = "install"
+	actRemove         = "remove"
+	actRun            = "run"
+	actSearch         = "search"
+	actClearSearch    = "clear_search"
+	actView           = "view"
+	actRegenAliases   = "regen_aliases"
+	actAliasName      = "alias_name"
+	actClearSelection = "clear_selection"
+	actShrinkLeft     = "shrink_left"
+	actGrowLeft       = "grow_left"
+)
+
+// Touche qui quitte toujours, même si "quit" est redéfini
+const quitKey = "ctrl+c"
+
+// Raccourci : action, touches (format de tea.KeyMsg.String()) et panels où il s'applique
+type keyBinding struct {
+	action string
+	keys   []string
+	panels []int  // Panels concernés (nil : tous)
+	status string // Libellé de la barre de statut ("" : absent, même libellé : regroupés)
+	help   string // Description de l'écran d'aide
+}
+
+// Le raccourci s'applique dans le panel
+func (b keyBinding) appliesTo(panel int) bool {
+	return b.panels == nil || slices.Contains(b.panels, panel)
+}
+
+// Raccourcis par défaut, dans l'ordre de la barre de statut
+var defaultBindings = []keyBinding{
+	{action: actHelp, keys: []string{"?"}, status: "Aide", help: "Aide (hors panel du plugin)"},
+	{action: actUp, keys: []string{"up", "k"}, panels: []int{1, 2}, status: "Navigation", help: "Monter"},
+	{action: actDown, keys: []string{"down", "j"}, panels: []int{1, 2}, status: "Navigation", help: "Descendre"},
+	{action: actNextPanel, keys: []string{"tab"}, status: "Panel", help: "Panel suivant"},
+	{action: actToggle, keys: []string{" "}, panels: []int{1}, status: "Replier/Déplier/Selectionner", help: "Sélectionner un plugin, replier/déplier un dépôt"},
+	{action: actConfirm, keys: []string{"enter"}, panels: []int{1}, status: "Validé", help: "Confirmer la sélection"},
+	{action: actInstall, keys: []string{"i"}, panels: []int{1}, status: "Installer", help: "Installer/Mettre à jour"},
+	{action: actRemove, keys: []string{"x"}, panels: []int{1}, status: "Supprimer", help: "Supprimer"},
+	{action: actRun, keys: []string{"e"}, panels: []int{1}, status: "Execution", help: "Exécuter le plugin"},
+	{action: actSearch, keys: []string{"/"}, panels: []int{1}, status: "Recherche", help: "Rechercher"},
+	{action: actClearSearch, keys: []string{"esc"}, panels: []int{1}, help: "Effacer la recherche"},
+	{action: actView, keys: []string{"v"}, panels: []int{1}, status: "Vue", help: "Vue suivante"},
+	{action: actRegenAliases, keys: []string{"a"}, panels: []int{1}, status: "Alias", help: "Régénérer les alias"},
+	{action: actAliasName, keys: []string{"n"}, panels: []int{1}, status: "Nom d'alias", help: "Nom de l'alias"},
+	{action: actFullScreen, keys: []string{"ctrl+g"}, status: "Plein écran", help: "Plugin actif en plein écran"},
+	{action: actClearSelection, keys: []string{"c"}, panels: []int{1}, status: "Annuler", help: "Annuler la sélection"},
+	{action: actQuit, keys: []string{"q"}, status: "Quitter", help: "Quitter"},
+	{action: actPanelHome, keys: []string{"0"}, help: "Panel de présentation"},
+	{action: actPanelRepos, keys: []string{"1"}, help: "Panel des dépôts"},
+	{action: actPanelLogs, keys: []string{"2"}, help: "Panel des logs"},
+	{action: actTop, keys: []string{"ctrl+up"}, panels: []int{2}, help: "Début des logs"},
+	{action: actBottom, keys: []string{"ctrl+down"}, panels: []int{2}, help: "Fin des logs"},
+	{action: actShrinkLeft, keys: []string{"<"}, panels: []int{0, 1, 2}, help: "Réduire la colonne gauche"},
+	{action: actGrowLeft, keys: []string{">"}, panels: []int{0, 1, 2}, help: "Élargir la colonne gauche"},
+}
+
+// Raccourcis actifs
+type keymap struct {
+	bindings []keyBinding
+}
+
+func defaultKeymap() keymap {
+	return keymap{bindings: slices.Clone(defaultBindings)}
+}
+
+// Action d'une touche dans un panel ("" si aucune)
+func (k keymap) action(panel int, msg tea.KeyMsg) string {
+	key := msg.String()
+	for _, b := range k.bindings {
+		if b.appliesTo(panel) && slices.Contains(b.keys, key) {
+			return b.action
+		}
+	}
+	return ""
+}
+
+// La touche déclenche l'action (quel que soit le panel)
+func (k keymap) matches(msg tea.KeyMsg, action string) bool {
+	return slices.Contains(k.binding(action).keys, msg.String())
+}
+
+func (k keymap) binding(action string) keyBinding {
+	for _, b := range k.bindings {
+		if b.action == action {
+			return b
+		}
+	}
+	return keyBinding{}
+}
+
+// Touches d'une action, pour l'affichage ("↑/k")
+func (k keymap) keyLabel(action string) string {
+	return keysLabel(k.binding(action).keys)
+}
+
+// Noms affichés de touches, séparés par "/"
+func keysLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, key := range keys {
+		labels[i] = keyName(key)
+	}
+	return strings.Join(labels, "/")
+}
+
+// Nom affiché d'une touche
+func keyName(key string) string {
+	switch key {
+	case " ":
+		return "Espace"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "enter":
+		return "Enter"
+	case "tab":
+		return "Tab"
+	case "esc":
+		return "Échap"
+	}
+	return key
+}
+
+// Barre de statut : première touche de chaque action, regroupées par libellé ("Navigation: ↑/↓ | Panel: Tab | ...")
+func (k keymap) statusHelp() string {
+	var labels []string
+	keys := make(map[string][]string)
+	for _, b := range k.bindings {
+		if b.status == "" {
+			continue
+		}
+		if _, ok := keys[b.status]; !ok {
+			labels = append(labels, b.status)
+		}
+		keys[b.status] = append(keys[b.status], keyName(b.keys[0]))
+	}
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = label + ": " + strings.Join(keys[label], "/")
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Raccourcis propres à un panel (sans les raccourcis globaux)
+func (k keymap) panelBindings(panel int) []keyBinding {
+	var bindings []keyBinding
+	for _, b := range k.bindings {
+		if b.panels != nil && b.appliesTo(panel) {
+			bindings = append(bindings, b)
+		}
+	}
+	return bindings
+}
+
+// Touches attribuées à plusieurs actions d'un même panel
+func keymapConflicts(bindings []keyBinding) []string {
+	var conflicts []string
+	for i, a := range bindings {
+		for _, b := range bindings[i+1:] {
+			if !sharePanel(a, b) {
+				continue
+			}
+			for _, key := range a.keys {
+				if slices.Contains(b.keys, key) {
+					conflicts = append(conflicts, fmt.Sprintf("touche %q attribuée à %s et %s", keyName(key), a.action, b.action))
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+func sharePanel(a, b keyBinding) bool {
+	if a.panels == nil || b.panels == nil {
+		return true
+	}
+	for _, panel := range a.panels {
+		if b.appliesTo(panel) {
+			return true
+		}
+	}
+	return false
+}
+
+// Charger les raccourcis de la section "keys" de repo.conf ({"quit": ["ctrl+q"], "remove": ["d", "delete"]}).
+// En cas d'erreur ou de conflit, les raccourcis par défaut sont gardés ; les avertissements sont retournés.
+func loadKeymap(configPath string) (keymap, []string) {
+	keys := defaultKeymap()
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return keys, nil
+	}
+	var config RepoConfig
+	if err := json.Unmarshal(data, &config); err != nil || len(config.Keys) == 0 {
+		return keys, nil
+	}
+
+	actions := make([]string, 0, len(config.Keys))
+	for action := range config.Keys {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	var warnings []string
+	custom := defaultKeymap()
+	for _, action := range actions {
+		i := slices.IndexFunc(custom.bindings, func(b keyBinding) bool { return b.action == action })
+		if i < 0 {
+			warnings = append(warnings, fmt.Sprintf("raccourci inconnu: %s", action))
+			continue
+		}
+
+		var actionKeys []string
+		for _, key := range config.Keys[action] {
+			switch {
+			case key == "space":
+				key = " "
+			case key == "":
+				continue
+			case key == quitKey:
+				warnings = append(warnings, fmt.Sprintf("touche %s réservée (quitter), ignorée pour %s", quitKey, action))
+				continue
+			}
+			actionKeys = append(actionKeys, key)
+		}
+		if len(actionKeys) == 0 {
+			warnings = append(warnings, fmt.Sprintf("aucune touche pour %s, touche par défaut conservée", action))
+			continue
+		}
+		custom.bindings[i].keys = actionKeys
+	}
+
+	if conflicts := keymapConflicts(custom.bindings); len(conflicts) > 0 {
+		warnings = append(warnings, conflicts...)
+		warnings = append(warnings, "raccourcis de repo.conf ignorés, raccourcis par défaut utilisés")
+		return keys, warnings
+	}
+	return custom, warnings
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestKeymapConflicts(t *testing.T) {
+	if conflicts := keymapConflicts(defaultBindings); len(conflicts) != 0 {
+		t.Errorf("raccourcis par défaut en conflit: %q", conflicts)
+	}
+
+	tests := []struct {
+		name     string
+		bindings []keyBinding
+		want     []string
+	}{
+		{
+			name: "même panel",
+			bindings: []keyBinding{
+				{action: actInstall, keys: []string{"i", "d"}, panels: []int{1}},
+				{action: actRemove, keys: []string{"d"}, panels: []int{1}},
+			},
+			want: []string{`touche "d" attribuée à install et remove`},
+		},
+		{
+			name: "raccourci global",
+			bindings: []keyBinding{
+				{action: actQuit, keys: []string{" "}},
+				{action: actToggle, keys: []string{" "}, panels: []int{1}},
+			},
+			want: []string{`touche "Espace" attribuée à quit et toggle`},
+		},
+		{
+			name: "panels différents",
+			bindings: []keyBinding{
+				{action: actInstall, keys: []string{"t"}, panels: []int{1}},
+				{action: actTop, keys: []string{"t"}, panels: []int{2}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := keymapConflicts(tt.bindings); !slices.Equal(got, tt.want) {
+			t.Errorf("%s : %q, attendu %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadKeymap(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		action   string
+		want     []string // Touches de action après chargement
+		warnings int
+	}{
+		{name: "fichier absent", action: actRemove, want: []string{"x"}},
+		{name: "redéfinition", config: `{"keys": {"remove": ["d", "delete"]}}`, action: actRemove, want: []string{"d", "delete"}},
+		{name: "espace", config: `{"keys": {"install": ["space"], "toggle": ["t"]}}`, action: actInstall, want: []string{" "}},
+		{name: "action inconnue", config: `{"keys": {"supprimer": ["d"], "remove": ["d"]}}`, action: actRemove, want: []string{"d"}, warnings: 1},
+		{name: "ctrl+c réservé", config: `{"keys": {"run": ["ctrl+c", "r"]}}`, action: actRun, want: []string{"r"}, warnings: 1},
+		{name: "seulement ctrl+c", config: `{"keys": {"run": ["ctrl+c"]}}`, action: actRun, want: []string{"e"}, warnings: 2},
+		// Conflit : tous les raccourcis de repo.conf sont ignorés
+		{name: "conflit", config: `{"keys": {"install": ["x"], "quit": ["ctrl+q"]}}`, action: actQuit, want: []string{"q"}, warnings: 2},
+		{name: "conflit avec espace", config: `{"keys": {"install": ["space"]}}`, action: actInstall, want: []string{"i"}, warnings: 2},
+		{name: "repo.conf invalide", config: `{"keys": `, action: actRemove, want: []string{"x"}},
+	}
+
+	for _, tt := range tests {
+		configPath := filepath.Join(t.TempDir(), "repo.conf")
+		if tt.config != "" {
+			if err := os.WriteFile(configPath, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		keys, warnings := loadKeymap(configPath)
+		if got := keys.binding(tt.action).keys; !slices.Equal(got, tt.want) {
+			t.Errorf("%s : touches de %s %q, attendu %q", tt.name, tt.action, got, tt.want)
+		}
+		if len(warnings) != tt.warnings {
+			t.Errorf("%s : avertissements %q, attendu %d", tt.name, warnings, tt.warnings)
+		}
+	}
+}
+
+// La liste à cocher suit les raccourcis de repo.conf
+func TestDialogListKeys(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "repo.conf")
+	if err := os.WriteFile(configPath, []byte(`{"keys": {"down": ["s"], "toggle": ["m"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	keys, warnings := loadKeymap(configPath)
+	if len(warnings) != 0 {
+		t.Fatalf("avertissements %q", warnings)
+	}
+
+	list := &dialogList{items: []dialogListItem{{label: "a", checked: true}, {label: "b", checked: true}}}
+	m := model{keys: keys, dialog: &dialog{list: list}}
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("s")},
+		{Type: tea.KeyRunes, Runes: []rune("m")},
+		{Type: tea.KeyDown},  // Plus attribuée à down
+		{Type: tea.KeySpace}, // Plus attribuée à toggle
+	} {
+		m.dialog.handleKey(m, key)
+	}
+
+	if list.cursor != 1 {
+		t.Errorf("curseur %d, attendu 1", list.cursor)
+	}
+	if got := list.checkedIndexes(); !slices.Equal(got, []int{0}) {
+		t.Errorf("éléments cochés %v, attendu [0]", got)
+	}
+}
+
+// Confirmer et Annuler suivent les raccourcis de repo.conf, touches et libellés
+func TestDialogOptionKeys(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "repo.conf")
+	if err := os.WriteFile(configPath, []byte(`{"keys": {"confirm": ["o"], "clear_search": ["ctrl+x"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	keys, warnings := loadKeymap(configPath)
+	if len(warnings) != 0 {
+		t.Fatalf("avertissements %q", warnings)
+	}
+	theme, _ := builtinTheme("dark")
+
+	confirmed := false
+	m := model{keys: keys, theme: theme}
+	m.dialog = &dialog{title: "Confirmer", options: []dialogOption{
+		m.confirmOption("Confirmer", func(m model) (tea.Model, tea.Cmd) {
+			confirmed = true
+			return m, nil
+		}),
+		m.cancelOption(),
+	}}
+
+	box := ansi.Strip(m.dialog.render(theme, 60, 20))
+	if !strings.Contains(box, "[o] Confirmer") || !strings.Contains(box, "[ctrl+x] Annuler") {
+		t.Errorf("libellés des choix:\n%s", box)
+	}
+
+	// Plus attribuées à confirm et clear_search
+	for _, key := range []tea.KeyMsg{{Type: tea.KeyEnter}, {Type: tea.KeyEsc}} {
+		updated, _ := m.dialog.handleKey(m, key)
+		if updated.(model).dialog == nil {
+			t.Errorf("%q ferme la boîte", key.String())
+		}
+	}
+	updated, _ := m.dialog.handleKey(m, tea.KeyMsg{Type: tea.KeyCtrlX})
+	if updated.(model).dialog != nil || confirmed {
+		t.Error("ctrl+x n'annule pas")
+	}
+	updated, _ = m.dialog.handleKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if updated.(model).dialog != nil || !confirmed {
+		t.Error("o ne confirme pas")
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Dimensions de la mise en page
+const (
+	defaultLeftWidth = 35 // Largeur par défaut de la pile de panels gauches (hors bordures)
+	minLeftWidth     = 20
+	minRightWidth    = 30
+	splitStep        = 2 // Colonnes gagnées ou perdues par redimensionnement
+
+	// En dessous : une seule colonne, le panel actif en plein cadre sous des onglets
+	narrowWidth = minLeftWidth + minRightWidth + 4
+
+	// Hauteurs (barre de statut exclue) en dessous desquelles un panel gauche est replié
+	presentMinHeight = 24
+	logMinHeight     = 14
+
+	// Taille en dessous de laquelle rien n'est affiché
+	tinyWidth  = 24
+	tinyHeight = 8
+)
+
+// Zone de contenu d'un panel, bordures exclues (w ou h nul : panel masqué)
+type rect struct {
+	x, y, w, h int
+}
+
+func (r rect) visible() bool {
+	return r.w > 0 && r.h > 0
+}
+
+func (r rect) contains(x, y int) bool {
+	return r.visible() && x >= r.x && x < r.x+r.w && y >= r.y && y < r.y+r.h
+}
+
+// Mise en page de l'écran pour la taille et le panel actif
+type layout struct {
+	narrow  bool // Une colonne : onglets en tête, panel actif en dessous
+	present rect
+	install rect
+	log     rect
+	right   rect // Plugin, logs, détails ou présentation selon le panel actif
+}
+
+// Largeur de la pile gauche : défaut décalé par l'utilisateur, bornée pour laisser sa place au panel de droite
+func (m model) leftWidth() int {
+	return max(minLeftWidth, min(defaultLeftWidth+m.splitOffset, m.width-minRightWidth-4))
+}
+
+// Calculer la mise en page
+func (m model) layout() layout {
+	available := m.height - 1 // Hors barre de statut
+
+	if m.width < narrowWidth {
+		// Onglets sur la première ligne, un seul cadre en dessous
+		frame := rect{x: 1, y: 2, w: m.width - 2, h: available - 3}
+		if m.activePanel == 1 {
+			return layout{narrow: true, install: frame}
+		}
+		return layout{narrow: true, right: frame}
+	}
+
+	var l layout
+	leftWidth := m.leftWidth()
+	l.right = rect{x: leftWidth + 3, y: 1, w: m.width - leftWidth - 4, h: available - 2}
+
+	// Panels gauches empilés : présentation puis logs repliés quand la hauteur manque
+	rows := available
+	y := 1
+	if available >= presentMinHeight {
+		l.present = rect{x: 1, y: y, w: leftWidth, h: 1}
+		rows -= 3
+		y += 3
+	}
+
+	ratio := 0.4
+	if m.activePanel == 1 || m.activePanel == 3 {
+		ratio = 0.8
+	}
+	installHeight := rows - 2
+	if available >= logMinHeight {
+		installHeight = min(int(float64(available)*ratio), rows-5)
+		l.log = rect{x: 1, y: y + installHeight + 2, w: leftWidth, h: rows - installHeight - 4}
+	}
+	l.install = rect{x: 1, y: y, w: leftWidth, h: installHeight}
+	return l
+}
+
+// Logs visibles dans le panel de droite ; défilés, l'indicateur ▲ prend deux lignes
+func (m model) logRows(scrolled bool) int {
+	rows := m.layout().right.h - 3
+	if scrolled {
+		rows -= 2
+	}
+	return max(1, rows)
+}
+
+// Décalage maximal des logs : le plus ancien en bas de la fenêtre défilée
+func (m model) maxLogScroll() int {
+	return max(0, len(m.logs)-m.logRows(true))
+}
+
+// Onglets du mode une colonne
+type tab struct {
+	panel int
+	label string
+}
+
+func (m model) tabs() []tab {
+	tabs := []tab{{0, "[0] " + "Accueil"}, {1, "[1] " + m.viewTitle()}, {2, "[2] Log"}}
+	if m.embeddedTUI != nil {
+		tabs = append(tabs, tab{3, "[3] " + pluginName(m.runningTUI)})
+	}
+	return tabs
+}
+
+// Séparateur des onglets
+const tabSeparator = " │ "
+
+// Panel de l'onglet sous une colonne de la première ligne (-1 si aucun)
+func (m model) tabAt(x int) int {
+	start := 0
+	for _, t := range m.tabs() {
+		end := start + lipgloss.Width(t.label)
+		if x >= start && x < end {
+			return t.panel
+		}
+		start = end + lipgloss.Width(tabSeparator)
+	}
+	return -1
+}
+
+// Ligne des onglets, l'onglet actif en surbrillance
+func (m model) renderTabs(active lipgloss.Style, inactive lipgloss.Style) string {
+	var labels []string
+	for _, t := range m.tabs() {
+		if t.panel == m.activePanel {
+			labels = append(labels, active.Render(t.label))
+		} else {
+			labels = append(labels, inactive.Render(t.label))
+		}
+	}
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(strings.Join(labels, tabSeparator))
+}
+
+// Élargir (delta > 0) ou réduire la pile gauche
+func (m model) resizeSplit(delta int) model {
+	m.splitOffset += delta
+	// Garder l'écart effectif : au-delà des bornes, la touche inverse agit aussitôt
+	m.splitOffset = m.leftWidth() - defaultLeftWidth
+	return m
+}
+
+// Ajuster un contenu à son cadre : lignes repliées à la largeur, puis coupées à la hauteur
+func fitContent(content string, width int, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	lines := strings.Split(lipgloss.NewStyle().Width(width).Render(content), "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Marque d'un texte tronqué
+const ellipsis = "..."
+
+// Tronquer un texte à une largeur d'affichage (colonnes du terminal : accents, emojis et caractères larges,
+// séquences ANSI ignorées), terminé par "..." s'il dépasse
+func truncateText(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if ansi.StringWidth(text) <= width {
+		return text
+	}
+	if width <= len(ellipsis) {
+		return ansi.Truncate(text, width, "")
+	}
+	return ansi.Truncate(text, width, ellipsis)
+}
+
+// Compléter un texte par des espaces jusqu'à une largeur d'affichage
+func padText(text string, width int) string {
+	return text + strings.Repeat(" ", max(0, width-ansi.StringWidth(text)))
+}
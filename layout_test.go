@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{text: "Journal.so", width: 20, want: "Journal.so"},
+		{text: "Journal.so", width: 10, want: "Journal.so"},
+		{text: "Récupération des dépôts", width: 12, want: "Récupérat..."},
+		{text: "✅ Toutes les opérations terminées!", width: 12, want: "✅ Toutes..."},
+		{text: "🗑️ Journal.so supprimé!", width: 8, want: "🗑️ Jo..."},
+		{text: "⛔ Réseau.so sélectionné dans TWilhem/Plugin", width: 20, want: "⛔ Réseau.so séle..."},
+		{text: "插件管理器.so", width: 8, want: "插件..."},
+		{text: "插件管理器.so", width: 4, want: "..."},
+		{text: "插件管理器.so", width: 2, want: "插"},
+		{text: "Échap", width: 3, want: "Éch"},
+		{text: "Échap", width: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		got := truncateText(tt.text, tt.width)
+		if got != tt.want {
+			t.Errorf("truncateText(%q, %d) = %q, attendu %q", tt.text, tt.width, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("truncateText(%q, %d) coupe un caractère: %q", tt.text, tt.width, got)
+		}
+		if w := ansi.StringWidth(got); w > max(tt.width, 0) {
+			t.Errorf("truncateText(%q, %d) fait %d colonnes", tt.text, tt.width, w)
+		}
+	}
+}
+
+func TestTruncateTextStyled(t *testing.T) {
+	styled := lipgloss.NewStyle().Bold(true).Render("Démarrage du traitement de 3 Plugin(s)")
+	got := truncateText(styled, 15)
+	if w := ansi.StringWidth(got); w != 15 {
+		t.Errorf("largeur %d, attendu 15: %q", w, got)
+	}
+	if plain := ansi.Strip(got); plain != "Démarrage du..." {
+		t.Errorf("texte %q, attendu %q", plain, "Démarrage du...")
+	}
+}
+
+func TestPadText(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+	}{
+		{text: "▼ ✓ TWilhem/Plugin", width: 30},
+		{text: "✓  → Réseau.so", width: 30},
+		{text: "插件.so", width: 12},
+		{text: "Dépôt trop long pour la colonne", width: 10},
+	}
+
+	for _, tt := range tests {
+		got := padText(tt.text, tt.width)
+		if !strings.HasPrefix(got, tt.text) {
+			t.Errorf("padText(%q, %d) = %q", tt.text, tt.width, got)
+		}
+		if w := ansi.StringWidth(got); w != max(tt.width, ansi.StringWidth(tt.text)) {
+			t.Errorf("padText(%q, %d) fait %d colonnes", tt.text, tt.width, w)
+		}
+	}
+}
+
+func TestStatusBarRender(t *testing.T) {
+	commands := defaultKeymap().statusHelp()
+	tests := []struct {
+		bar   statusBar
+		width int
+		want  string
+	}{
+		{bar: statusBar{message: "✅ Toutes les opérations terminées!"}, width: 80, want: "✅ Toutes les opérations terminées!"},
+		{bar: statusBar{message: "✅ Toutes les opérations terminées!"}, width: 20, want: "✅ Toutes les opé..."},
+		{bar: statusBar{commands: commands}, width: 30, want: "Aide: ? | Navigation: ↑/↓ |..."},
+		{bar: statusBar{message: "Récupération ⠋", commands: commands}, width: 40, want: "Récupération ⠋ | Aide: ? | Navigation..."},
+		{bar: statusBar{message: "Récupération ⠋", commands: commands}, width: 18, want: "Récupération ⠋"},
+		{bar: statusBar{message: "Recherche : Entrée pour valider, Échap pour effacer", commands: commands}, width: 25, want: "Recherche : Entrée pou..."},
+	}
+
+	for _, tt := range tests {
+		got := tt.bar.render(tt.width)
+		if got != tt.want {
+			t.Errorf("render(%d) = %q, attendu %q", tt.width, got, tt.want)
+		}
+		if w := ansi.StringWidth(got); w > tt.width {
+			t.Errorf("render(%d) fait %d colonnes: %q", tt.width, w, got)
+		}
+	}
+}
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		panel         int
+		splitOffset   int
+		want          layout
+	}{
+		{
+			name: "étroit, panel des plugins", width: 40, height: 20, panel: 1,
+			want: layout{narrow: true, install: rect{1, 2, 38, 16}},
+		},
+		{
+			name: "étroit, autre panel", width: 40, height: 20, panel: 2,
+			want: layout{narrow: true, right: rect{1, 2, 38, 16}},
+		},
+		{
+			name: "limite du mode étroit", width: narrowWidth, height: 20, panel: 1,
+			want: layout{install: rect{1, 1, 20, 14}, log: rect{1, 17, 20, 1}, right: rect{23, 1, 30, 17}},
+		},
+		{
+			name: "court : logs repliés", width: 100, height: 12, panel: 1,
+			want: layout{install: rect{1, 1, 35, 9}, right: rect{38, 1, 61, 9}},
+		},
+		{
+			name: "moyen : présentation repliée", width: 100, height: 20, panel: 1,
+			want: layout{install: rect{1, 1, 35, 14}, log: rect{1, 17, 35, 1}, right: rect{38, 1, 61, 17}},
+		},
+		{
+			name: "large", width: 120, height: 40, panel: 0,
+			want: layout{present: rect{1, 1, 35, 1}, install: rect{1, 4, 35, 15}, log: rect{1, 21, 35, 17}, right: rect{38, 1, 81, 37}},
+		},
+		{
+			name: "large, panel des plugins", width: 120, height: 40, panel: 1,
+			want: layout{present: rect{1, 1, 35, 1}, install: rect{1, 4, 35, 31}, log: rect{1, 37, 35, 1}, right: rect{38, 1, 81, 37}},
+		},
+		{
+			name: "colonne gauche élargie jusqu'à la borne", width: 80, height: 30, panel: 0, splitOffset: 20,
+			want: layout{present: rect{1, 1, 46, 1}, install: rect{1, 4, 46, 11}, log: rect{1, 17, 46, 11}, right: rect{49, 1, 30, 27}},
+		},
+		{
+			name: "colonne gauche réduite jusqu'à la borne", width: 120, height: 30, panel: 0, splitOffset: -30,
+			want: layout{present: rect{1, 1, 20, 1}, install: rect{1, 4, 20, 11}, log: rect{1, 17, 20, 11}, right: rect{23, 1, 96, 27}},
+		},
+	}
+
+	for _, tt := range tests {
+		m := model{width: tt.width, height: tt.height, activePanel: tt.panel, splitOffset: tt.splitOffset}
+		got := m.layout()
+		if got != tt.want {
+			t.Errorf("%s : %+v, attendu %+v", tt.name, got, tt.want)
+		}
+
+		// Cadres (bordures comprises) dans l'écran, barre de statut exclue
+		for _, r := range []rect{got.present, got.install, got.log, got.right} {
+			if r.visible() && (r.x+r.w+1 > tt.width || r.y+r.h+1 > tt.height-1) {
+				t.Errorf("%s : %+v dépasse %dx%d", tt.name, r, tt.width, tt.height)
+			}
+		}
+	}
+}
+
+// Boîtes de dialogue et aide bornées à la taille du terminal
+func TestDialogRenderSize(t *testing.T) {
+	th, _ := builtinTheme("dark")
+	list := &dialogList{}
+	for i := range 20 {
+		list.items = append(list.items, dialogListItem{group: "à installer", label: fmt.Sprintf("%-16s Plugin%02d.so (TWilhem/Plugin)", "à installer", i), checked: true})
+	}
+	dialogs := map[string]*dialog{
+		"confirmation": {
+			title:   "Confirmer les opérations",
+			lines:   []string{"↑/k, ↓/j : naviguer, Espace : cocher/décocher", ""},
+			list:    list,
+			options: []dialogOption{{keys: []string{"enter"}, label: "Confirmer"}, {keys: []string{"esc"}, label: "Annuler"}},
+		},
+		"saisie": {
+			title:   "Nom de l'alias de Journal.so",
+			lines:   []string{"Nom vide : revenir au nom du fichier"},
+			input:   &dialogInput{value: strings.Repeat("journal-", 10)},
+			options: []dialogOption{{keys: []string{"esc"}, label: "Annuler"}},
+		},
+	}
+
+	for name, d := range dialogs {
+		for _, size := range []struct{ width, height int }{{30, 10}, {80, 24}} {
+			box := d.render(th, size.width, size.height)
+			if w := lipgloss.Width(box); w > size.width {
+				t.Errorf("%s à %dx%d : %d colonnes", name, size.width, size.height, w)
+			}
+			if h := lipgloss.Height(box); h > size.height {
+				t.Errorf("%s à %dx%d : %d lignes", name, size.width, size.height, h)
+			}
+			if !strings.Contains(ansi.Strip(box), "Annuler") {
+				t.Errorf("%s à %dx%d sans ses choix:\n%s", name, size.width, size.height, ansi.Strip(box))
+			}
+		}
+	}
+}
+
+// Logs défilés jusqu'au bout : le plus ancien reste visible, en mode étroit comme large
+func TestLogScroll(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		want          int // Décalage maximal pour 30 logs
+	}{
+		{name: "étroit", width: 40, height: 20, want: 19},
+		{name: "large", width: 100, height: 20, want: 18},
+		{name: "logs moins nombreux que les lignes", width: 120, height: 60, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("HOME", t.TempDir())
+		baseDir := t.TempDir()
+		m := initialModel(baseDir, filepath.Join(baseDir, "repo.conf"))
+		m.loading = false
+		m.width, m.height, m.activePanel = tt.width, tt.height, 2
+		m.logs = nil
+		for i := range 30 {
+			m.logs = append(m.logs, fmt.Sprintf("log %02d", i))
+		}
+
+		if got := m.maxLogScroll(); got != tt.want {
+			t.Errorf("%s : décalage maximal %d, attendu %d", tt.name, got, tt.want)
+		}
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlDown})
+		m = updated.(model)
+		if m.scrollOffset != tt.want {
+			t.Errorf("%s : décalage %d après Fin des logs, attendu %d", tt.name, m.scrollOffset, tt.want)
+		}
+		updated, _ = m.Update(tea.MouseMsg{X: m.layout().right.x, Y: m.layout().right.y, Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+		m = updated.(model)
+		if m.scrollOffset != tt.want {
+			t.Errorf("%s : décalage %d après la molette, attendu %d", tt.name, m.scrollOffset, tt.want)
+		}
+		if view := m.View(); !strings.Contains(view, "log 00") {
+			t.Errorf("%s : log le plus ancien absent:\n%s", tt.name, view)
+		}
+	}
+}
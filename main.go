@@ -2,12 +2,10 @@ package main
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"os/user"
 	"path/filepath"
 	"plugin"
 	"strings"
@@ -16,6 +14,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // Structure pour les fichiers GitHub
@@ -23,28 +22,56 @@ type GitHubFile struct {
 	Name        string `json:"name"`
 	Type        string `json:"type"`
 	DownloadURL string `json:"download_url"`
+	SHA         string `json:"sha"`  // SHA git du contenu (identifie la version)
+	Size        int64  `json:"size"` // Taille en octets
 }
 
 // Structure pour le fichier repo.conf
 type RepoConfig struct {
-	Repos []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
+	Policy  string              `json:"policy"`  // "warn" (défaut) ou "block" pour les dépôts sans clé
+	Release string              `json:"release"` // Source des publications de Pannel (self-update)
+	Keys    map[string][]string `json:"keys"`    // Raccourcis redéfinis, clé: action
+	Theme   string              `json:"theme"`   // Thème : auto (défaut), dark, light, high-contrast, mono ou un thème de "themes"
+	Themes  map[string]theme    `json:"themes"`  // Thèmes personnalisés, clé: nom
+	Repos   []struct {
+		Name      string `json:"name"`
+		URL       string `json:"url"`
+		PublicKey string `json:"public_key"` // Clé ed25519 (base64) signant les fichiers du dépôt
 	} `json:"repos"`
 }
 
 // Structure pour un repository
 type Repository struct {
-	Name      string
-	URL       string
-	Files     []GitHubFile
-	Collapsed bool // true = replié, false = déplié
+	Name       string
+	URL        string
+	Files      []GitHubFile
+	Manifests  map[string]GitHubFile // Manifestes publiés, clé: nom du plugin
+	Signatures map[string]GitHubFile // Signatures publiées, clé: nom du fichier signé
+	PublicKey  string                // Clé de signature déclarée dans repo.conf ("" = non signé)
+	Blocked    bool                  // Dépôt non signé refusé par la politique, ou clé invalide
+	KeyError   error                 // Clé de repo.conf illisible (dépôt bloqué)
+	Collapsed  bool                  // true = replié, false = déplié
 }
 
 // Message contenant la liste des fichiers
 type filesLoadedMsg struct {
-	repos []Repository
-	err   error
+	repos      []Repository
+	repoErrors []error // Dépôts ignorés car injoignables (repoError)
+	err        error
+}
+
+// Dépôt de repo.conf injoignable
+type repoError struct {
+	repo string
+	err  error
+}
+
+func (e repoError) Error() string {
+	return fmt.Sprintf("%s: %v", e.repo, e.err)
+}
+
+func (e repoError) Unwrap() error {
+	return e.err
 }
 
 // Message pour l'animation du spinner
@@ -53,7 +80,11 @@ type tickMsg time.Time
 // Message de téléchargement/suppression
 type operationCompleteMsg struct {
 	filename  string
+	repo      string // Dépôt du plugin téléchargé
 	operation string // "download" ou "delete"
+	purged    bool   // Données du plugin supprimées avec lui
+	verified  bool   // Signature du dépôt vérifiée
+	unsigned  bool   // Téléchargé depuis un dépôt sans clé
 	err       error
 }
 
@@ -61,8 +92,10 @@ type allOperationsCompleteMsg struct{}
 
 // Message pour le chargement de plugin
 type pluginLoadedMsg struct {
-	model tea.Model
-	err   error
+	model    tea.Model
+	err      error
+	dev      bool   // true = rechargement du mode développement
+	filename string // Copie chargée (mode développement)
 }
 
 // Template pour la barre de statut
@@ -71,29 +104,21 @@ type statusBar struct {
 	commands string
 }
 
+// Barre de statut ajustée à la largeur (en colonnes), le message passant avant les commandes
 func (s statusBar) render(width int) string {
 	if s.message != "" && s.commands != "" {
 		// Calculer l'espace disponible pour les commandes et massages
-		messageLen := len(s.message)
 		separator := " | "
-		availableForCommands := width - messageLen - len(separator) - 2
+		availableForCommands := width - ansi.StringWidth(s.message) - ansi.StringWidth(separator)
 
-		commands := s.commands
-		if len(commands) > availableForCommands && availableForCommands > 3 {
-			commands = commands[:availableForCommands-3] + "..."
+		if availableForCommands <= len(ellipsis) {
+			return truncateText(s.message, width)
 		}
-		return fmt.Sprintf("%s%s%s", s.message, separator, commands)
+		return fmt.Sprintf("%s%s%s", s.message, separator, truncateText(s.commands, availableForCommands))
 	} else if s.message != "" {
-		return s.message
+		return truncateText(s.message, width)
 	} else if s.commands != "" {
-		// Calculer l'espace disponible pour juste les commandes
-		availableForCommands := width
-
-		commands := s.commands
-		if len(commands) > availableForCommands && availableForCommands > 3 {
-			commands = commands[:availableForCommands-3] + "..."
-		}
-		return commands
+		return truncateText(s.commands, width)
 	}
 	return ""
 
@@ -106,6 +131,7 @@ type displayLine struct {
 	fileIdx  int
 	text     string
 	isHeader bool
+	matches  []int // Positions (en runes) du nom correspondant à la recherche
 }
 
 // Le modèle contient l'état de l'application
@@ -115,29 +141,46 @@ type model struct {
 	repos            []Repository
 	loading          bool
 	processing       bool
+	pending          int // Opérations du lot pas encore terminées
 	err              error
 	spinnerFrame     int
 	cursor           int
 	statusMsg        string
 	localFiles       map[string]bool
-	selected         map[string]bool // Clé: "repoIdx:fileIdx"
-	cmdTemplate      string          // Template du status
-	activePanel      int             // 0 = Presentation, 1 = Install, 2 = Log, 3 = Pannel Droite
-	logs             []string        // Historique des logs
-	tuiOutput        []string        // Sortie du TUI en cours d'exécution
-	runningTUI       string          // Nom du fichier TUI en cours d'exécution
-	embeddedTUI      tea.Model       //
-	tuiMutex         *sync.Mutex     // Mutex pour l'accès concurrent
-	scrollOffset     int             // Offset pour le scroll du contenu
-	embeddedPluginID string          // Id plugin
-	pluginDir        string          //
-	displayLines     []displayLine   // Lignes à afficher
+	selected         map[string]bool          // Clé: "repoIdx:fileIdx"
+	cmdTemplate      string                   // Template du status
+	activePanel      int                      // 0 = Presentation, 1 = Install, 2 = Log, 3 = Pannel Droite
+	logs             []string                 // Historique des logs
+	tuiOutput        []string                 // Sortie du TUI en cours d'exécution
+	runningTUI       string                   // Nom du fichier TUI en cours d'exécution
+	embeddedTUI      tea.Model                //
+	tuiMutex         *sync.Mutex              // Mutex pour l'accès concurrent
+	scrollOffset     int                      // Offset pour le scroll du contenu
+	embeddedPluginID string                   // Id plugin
+	pluginDir        string                   //
+	configPath       string                   // Fichier de configuration des dépôts (repo.conf)
+	displayLines     []displayLine            // Lignes à afficher
+	fullScreen       bool                     // true = plugin actif en plein écran
+	dev              *devWatcher              // Surveillance du mode développement (nil si inactif)
+	dialog           *dialog                  // Boîte de dialogue modale (nil si aucune)
+	purgeData        bool                     // Supprimer les données des plugins supprimés (traitement en cours)
+	searching        bool                     // Saisie de la recherche en cours (panel 1)
+	searchQuery      string                   // Filtre du panel 1 ("" = aucun)
+	descriptions     map[string]string        // Descriptions des manifestes connus, clé: nom du fichier
+	view             int                      // Vue du panel 1 (viewAll, viewInstalled...)
+	installed        installedState           // Versions installées (détection des mises à jour)
+	details          map[string]pluginDetails // Détails chargés des plugins, clé: "dépôt/fichier"
+	detailsLoading   map[string]bool          // Détails en cours de chargement
+	keys             keymap                   // Raccourcis clavier actifs
+	theme            theme                    // Couleurs de l'interface
+	help             *helpScreen              // Écran d'aide (nil si fermé)
+	splitOffset      int                      // Écart de la largeur de la colonne gauche au défaut ("<" / ">")
 }
 
 var spinnerFrames = []string{"|", "/", "-", "\\"}
 
 // Initialisation
-func initialModel(baseDir string) model {
+func initialModel(baseDir string, configPath string) model {
 	// pluginDir = baseDir/.Plugin (si baseDir est un dossier fourni)
 	pluginDir := filepath.Join(baseDir, "Plugin")
 
@@ -146,25 +189,44 @@ func initialModel(baseDir string) model {
 		os.Mkdir(pluginDir, 0755)
 	}
 
-	return model{
-		loading:      true,
-		processing:   false,
-		repos:        []Repository{},
-		spinnerFrame: 0,
-		cursor:       0,
-		localFiles:   make(map[string]bool),
-		selected:     make(map[string]bool),
-		cmdTemplate:  "Navigation: ↑/↓ | Panel: Tab | Replier/Déplier/Selectionner: Espace | Validé: Enter | Execution: e | Annuler: c | Quitter: q",
-		activePanel:  0,
-		logs:         []string{},
-		tuiOutput:    []string{},
-		runningTUI:   "",
-		embeddedTUI:  nil,
-		tuiMutex:     &sync.Mutex{},
-		scrollOffset: 0,
-		pluginDir:    pluginDir,
-		displayLines: []displayLine{},
+	keys, keyWarnings := loadKeymap(configPath)
+	colors, themeWarnings := loadTheme(configPath)
+
+	m := model{
+		loading:        true,
+		processing:     false,
+		repos:          []Repository{},
+		spinnerFrame:   0,
+		cursor:         0,
+		localFiles:     make(map[string]bool),
+		selected:       make(map[string]bool),
+		descriptions:   make(map[string]string),
+		installed:      installedState{Plugins: make(map[string]installedPlugin)},
+		details:        make(map[string]pluginDetails),
+		detailsLoading: make(map[string]bool),
+		cmdTemplate:    keys.statusHelp(),
+		activePanel:    0,
+		logs:           []string{},
+		tuiOutput:      []string{},
+		runningTUI:     "",
+		embeddedTUI:    nil,
+		tuiMutex:       &sync.Mutex{},
+		scrollOffset:   0,
+		pluginDir:      pluginDir,
+		configPath:     configPath,
+		displayLines:   []displayLine{},
+		keys:           keys,
+		theme:          colors,
+	}
+	// Raccourcis de repo.conf invalides ou en conflit
+	for _, warning := range keyWarnings {
+		m.addLog("⚠️ Raccourcis: " + warning)
+	}
+	// Thème inconnu ou couleurs invalides
+	for _, warning := range themeWarnings {
+		m.addLog("⚠️ Thème: " + warning)
 	}
+	return m
 }
 
 // Ajouter un log avec timestamp
@@ -174,11 +236,33 @@ func (m *model) addLog(message string) {
 	m.logs = append(m.logs, logEntry)
 }
 
-// Construire la liste des lignes à afficher
+// Construire la liste des lignes à afficher, filtrée par la vue et la recherche (seuls les dépôts ayant des résultats gardent leur en-tête)
 func (m *model) buildDisplayLines() {
 	m.displayLines = []displayLine{}
 
 	for repoIdx, repo := range m.repos {
+		var files []displayLine
+		for fileIdx, file := range repo.Files {
+			if !m.inView(m.view, repoIdx, fileIdx) {
+				continue
+			}
+			matches, ok := matchPlugin(m.searchQuery, file.Name, m.descriptions[file.Name])
+			if !ok {
+				continue
+			}
+			files = append(files, displayLine{
+				isRepo:   false,
+				repoIdx:  repoIdx,
+				fileIdx:  fileIdx,
+				text:     file.Name,
+				isHeader: false,
+				matches:  matches,
+			})
+		}
+		if (m.searchQuery != "" || m.view != viewAll) && len(files) == 0 {
+			continue
+		}
+
 		// Ajouter la ligne du repository (header)
 		m.displayLines = append(m.displayLines, displayLine{
 			isRepo:   true,
@@ -190,21 +274,30 @@ func (m *model) buildDisplayLines() {
 
 		// Si le repo n'est pas replié, ajouter ses fichiers
 		if !repo.Collapsed {
-			for fileIdx, file := range repo.Files {
-				m.displayLines = append(m.displayLines, displayLine{
-					isRepo:   false,
-					repoIdx:  repoIdx,
-					fileIdx:  fileIdx,
-					text:     file.Name,
-					isHeader: false,
-				})
+			m.displayLines = append(m.displayLines, files...)
+		}
+	}
+}
+
+// Descriptions des manifestes des plugins installés (utilisées par la recherche)
+func (m *model) loadDescriptions() {
+	for _, repo := range m.repos {
+		for _, file := range repo.Files {
+			if !m.localFiles[localKey(repo.Name, file.Name)] {
+				continue
+			}
+			if manifest, err := readLocalManifest(file.Name, m.pluginDir); err == nil && manifest != nil {
+				m.descriptions[file.Name] = manifest.Description
 			}
 		}
 	}
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(fetchFiles(m.pluginDir), tickCmd())
+	if m.dev != nil {
+		return tea.Batch(fetchFiles(m.configPath), tickCmd(), devTickCmd())
+	}
+	return tea.Batch(fetchFiles(m.configPath), tickCmd())
 }
 
 // Commande pour le tick du spinner
@@ -235,12 +328,10 @@ func fetchFromURL(url string) ([]GitHubFile, error) {
 }
 
 // Commande pour récupérer les fichiers
-func fetchFiles(pluginDir string) tea.Cmd {
+func fetchFiles(configPath string) tea.Cmd {
 	return func() tea.Msg {
 		var repos []Repository
-
-		// Chemin du fichier de configuration
-		configPath := filepath.Join(filepath.Dir(pluginDir), "repo.conf")
+		var repoErrors []error
 
 		// Vérifier si le fichier repo.conf existe
 		if _, err := os.Stat(configPath); err == nil {
@@ -254,27 +345,43 @@ func fetchFiles(pluginDir string) tea.Cmd {
 			if err := json.Unmarshal(configData, &config); err != nil {
 				return filesLoadedMsg{repos: nil, err: fmt.Errorf("erreur parsing repo.conf: %v", err)}
 			}
+			if config.Policy != "" && config.Policy != policyWarn && config.Policy != policyBlock {
+				return filesLoadedMsg{repos: nil, err: fmt.Errorf("politique inconnue dans repo.conf: %s", config.Policy)}
+			}
 
 			// Parcourir toutes les URLs du fichier de configuration
 			for _, repoConf := range config.Repos {
 				files, err := fetchFromURL(repoConf.URL)
 				if err != nil {
 					// Continuer même en cas d'erreur sur une URL
+					repoErrors = append(repoErrors, repoError{repo: repoConf.Name, err: err})
 					continue
 				}
+				files, signatures := splitSignatures(files)
+				files, manifests := splitManifests(files)
+				// Une clé illisible ferait échouer chaque vérification : le dépôt est bloqué dès le chargement
+				var keyErr error
+				if repoConf.PublicKey != "" {
+					_, keyErr = parsePublicKey(repoConf.PublicKey)
+				}
 				repos = append(repos, Repository{
-					Name:      repoConf.Name,
-					URL:       repoConf.URL,
-					Files:     files,
-					Collapsed: false,
+					Name:       repoConf.Name,
+					URL:        repoConf.URL,
+					Files:      files,
+					Manifests:  manifests,
+					Signatures: signatures,
+					PublicKey:  repoConf.PublicKey,
+					Blocked:    keyErr != nil || (repoConf.PublicKey == "" && config.Policy == policyBlock),
+					KeyError:   keyErr,
+					Collapsed:  false,
 				})
 			}
 
 			if len(repos) == 0 {
-				return filesLoadedMsg{repos: nil, err: fmt.Errorf("aucun repo trouvé dans repo.conf")}
+				return filesLoadedMsg{repos: nil, repoErrors: repoErrors, err: fmt.Errorf("aucun repo trouvé dans repo.conf")}
 			}
 
-			return filesLoadedMsg{repos: repos, err: nil}
+			return filesLoadedMsg{repos: repos, repoErrors: repoErrors, err: nil}
 		}
 
 		// Si repo.conf n'existe pas, utiliser l'URL par défaut
@@ -284,19 +391,23 @@ func fetchFiles(pluginDir string) tea.Cmd {
 			return filesLoadedMsg{repos: nil, err: err}
 		}
 
+		files, signatures := splitSignatures(files)
+		files, manifests := splitManifests(files)
 		repos = append(repos, Repository{
-			Name:      "TWilhem/Plugin",
-			URL:       defaultURL,
-			Files:     files,
-			Collapsed: false,
+			Name:       "TWilhem/Plugin",
+			URL:        defaultURL,
+			Files:      files,
+			Manifests:  manifests,
+			Signatures: signatures,
+			Collapsed:  false,
 		})
 
 		return filesLoadedMsg{repos: repos, err: nil}
 	}
 }
 
-// Commande pour télécharger un fichier
-func downloadFile(file GitHubFile, pluginDir string) tea.Cmd {
+// Commande pour télécharger un fichier (vérifié avant écriture si check n'est pas nil)
+func downloadFile(file GitHubFile, pluginDir string, check *signatureCheck) tea.Cmd {
 	return func() tea.Msg {
 		if file.Type != "file" || file.DownloadURL == "" {
 			return operationCompleteMsg{filename: file.Name, operation: "download", err: fmt.Errorf("impossible de télécharger un dossier")}
@@ -308,139 +419,220 @@ func downloadFile(file GitHubFile, pluginDir string) tea.Cmd {
 		}
 		defer resp.Body.Close()
 
-		filePath := filepath.Join(pluginDir, file.Name)
-		out, err := os.Create(filePath)
+		if resp.StatusCode != 200 {
+			return operationCompleteMsg{filename: file.Name, operation: "download", err: fmt.Errorf("HTTP %d pour %s", resp.StatusCode, file.DownloadURL)}
+		}
+
+		// Télécharger en mémoire : rien n'est écrit avant la vérification de signature
+		content, err := io.ReadAll(resp.Body)
 		if err != nil {
 			return operationCompleteMsg{filename: file.Name, operation: "download", err: err}
 		}
-		defer out.Close()
 
-		_, err = io.Copy(out, resp.Body)
-		if err != nil {
+		if check != nil {
+			if err := check.verify(file.Name, content); err != nil {
+				return operationCompleteMsg{filename: file.Name, operation: "download", err: err}
+			}
+		}
+
+		filePath := filepath.Join(pluginDir, file.Name)
+		if err := os.WriteFile(filePath, content, 0644); err != nil {
 			return operationCompleteMsg{filename: file.Name, operation: "download", err: err}
 		}
 
-		// Rendre le fichier exécutable
+		// Droits 0644 même si le fichier existait avec d'autres droits (WriteFile ne les change pas)
 		os.Chmod(filePath, 0644)
 
-		return operationCompleteMsg{filename: file.Name, operation: "download", err: nil}
+		return operationCompleteMsg{filename: file.Name, operation: "download", verified: check != nil, err: nil}
 	}
 }
 
-// Commande pour supprimer un fichier
-func deleteFile(filename string, pluginDir string) tea.Cmd {
-	return func() tea.Msg {
-		filePath := filepath.Join(pluginDir, filename)
-		err := os.Remove(filePath)
-		return operationCompleteMsg{filename: filename, operation: "delete", err: err}
+// Commande pour télécharger un plugin et son manifeste (enregistré sous <plugin>.json)
+func downloadPlugin(repo Repository, file GitHubFile, pluginDir string) tea.Cmd {
+	if repo.Blocked {
+		err := fmt.Errorf("dépôt %s non signé, bloqué par la politique", repo.Name)
+		if repo.KeyError != nil {
+			err = fmt.Errorf("dépôt %s bloqué: %v", repo.Name, repo.KeyError)
+		}
+		return func() tea.Msg {
+			return operationCompleteMsg{filename: file.Name, operation: "download", err: err}
+		}
 	}
-}
 
-// Charger un plugin externe
-func loadPlugin(filename string, pluginDir string) tea.Cmd {
+	download := downloadFile(file, pluginDir, repo.signatureCheckFor(file))
 	return func() tea.Msg {
-		// Ouvrir le plugin
-		pluginPath := filepath.Join(pluginDir, filename)
-		plug, err := plugin.Open(pluginPath)
-		if err != nil {
-			return pluginLoadedMsg{model: nil, err: fmt.Errorf("erreur ouverture plugin: %v", err)}
+		// Un même nom de fichier dans deux dépôts : le second écraserait le premier (et son alias)
+		if state, err := loadInstalled(filepath.Dir(pluginDir)); err == nil {
+			if other := state.Plugins[file.Name].Repo; other != "" && other != repo.Name {
+				return operationCompleteMsg{filename: file.Name, operation: "download", err: fmt.Errorf("déjà installé depuis %s, le supprimer avant d'installer celui de %s", other, repo.Name)}
+			}
 		}
 
-		// Chercher le symbole NewTUI
-		symNewTUI, err := plug.Lookup("NewTUI")
-		if err != nil {
-			return pluginLoadedMsg{model: nil, err: fmt.Errorf("symbole NewTUI non trouvé: %v", err)}
+		msg := download().(operationCompleteMsg)
+		msg.repo = repo.Name
+		msg.unsigned = repo.PublicKey == ""
+		if msg.err != nil {
+			return msg
 		}
 
-		// Convertir en fonction
-		newTUI, ok := symNewTUI.(func() tea.Model)
+		manifest, ok := repo.manifestFor(file)
 		if !ok {
-			return pluginLoadedMsg{model: nil, err: fmt.Errorf("format de plugin invalide")}
+			// Ne pas garder le manifeste d'une version précédente
+			os.Remove(filepath.Join(pluginDir, manifestName(file.Name)))
+		} else {
+			// Le manifeste est signé sous son nom d'origine, puis enregistré sous le nom du plugin
+			check := repo.signatureCheckFor(manifest)
+			localManifest := manifest
+			localManifest.Name = manifestName(file.Name)
+			if res := downloadFile(localManifest, pluginDir, check)().(operationCompleteMsg); res.err != nil {
+				// Un plugin sans son manifeste vérifié ne doit pas rester installé
+				os.Remove(filepath.Join(pluginDir, file.Name))
+				msg.err = fmt.Errorf("manifeste: %v", res.err)
+				return msg
+			}
 		}
 
-		// Créer le modèle
-		tuiModel := newTUI()
-		return pluginLoadedMsg{model: tuiModel, err: nil}
+		// Version installée, pour détecter les mises à jour
+		if err := recordInstall(repo, file, filepath.Dir(pluginDir)); err != nil {
+			msg.err = fmt.Errorf("enregistrement de l'installation: %v", err)
+		}
+		return msg
 	}
 }
 
-// Traiter toutes les opérations sélectionnées
-func processSelectedFiles(m model) tea.Cmd {
-	var cmds []tea.Cmd
+// Commande pour supprimer un fichier (et ses données si purgeData)
+func deleteFile(filename string, pluginDir string, purgeData bool) tea.Cmd {
+	return func() tea.Msg {
+		filePath := filepath.Join(pluginDir, filename)
+		err := os.Remove(filePath)
+		// Le manifeste installé et l'état d'installation suivent le plugin
+		os.Remove(filepath.Join(pluginDir, manifestName(filename)))
+		if err == nil {
+			err = forgetInstall(filename, filepath.Dir(pluginDir))
+		}
+		if err != nil || !purgeData {
+			return operationCompleteMsg{filename: filename, operation: "delete", err: err}
+		}
 
-	for key := range m.selected {
-		parts := strings.Split(key, ":")
-		if len(parts) != 2 {
-			continue
+		if err := purgePluginData(pluginName(filename), filepath.Dir(pluginDir)); err != nil {
+			return operationCompleteMsg{filename: filename, operation: "delete", err: fmt.Errorf("purge des données: %v", err)}
 		}
+		return operationCompleteMsg{filename: filename, operation: "delete", purged: true}
+	}
+}
 
-		var repoIdx, fileIdx int
-		fmt.Sscanf(parts[0], "%d", &repoIdx)
-		fmt.Sscanf(parts[1], "%d", &fileIdx)
+// Charger un plugin externe
+func loadPlugin(filename string, pluginDir string) tea.Cmd {
+	return func() tea.Msg {
+		return loadPluginFile(filepath.Join(pluginDir, filename), pluginName(filename), filepath.Dir(pluginDir), nil)
+	}
+}
 
-		if repoIdx >= len(m.repos) || fileIdx >= len(m.repos[repoIdx].Files) {
-			continue
-		}
+// Ouvrir un fichier plugin et créer son modèle (name = espace de données du plugin, args = arguments de "run")
+func loadPluginFile(pluginPath string, name string, baseDir string, args []string) pluginLoadedMsg {
+	// Ouvrir le plugin
+	plug, err := plugin.Open(pluginPath)
+	if err != nil {
+		return pluginLoadedMsg{model: nil, err: fmt.Errorf("erreur ouverture plugin: %v", err)}
+	}
 
-		file := m.repos[repoIdx].Files[fileIdx]
+	// Chercher le symbole NewTUI
+	symNewTUI, err := plug.Lookup("NewTUI")
+	if err != nil {
+		return pluginLoadedMsg{model: nil, err: fmt.Errorf("symbole NewTUI non trouvé: %v", err)}
+	}
 
-		if m.localFiles[file.Name] {
-			cmds = append(cmds, deleteFile(file.Name, m.pluginDir))
-		} else {
-			cmds = append(cmds, downloadFile(file, m.pluginDir))
-		}
+	// Convertir en fonction
+	newTUI, ok := symNewTUI.(func() tea.Model)
+	if !ok {
+		return pluginLoadedMsg{model: nil, err: fmt.Errorf("format de plugin invalide")}
 	}
 
-	cmds = append(cmds, func() tea.Msg {
-		return allOperationsCompleteMsg{}
-	})
+	// Transmettre l'API de l'hôte (stockage persistant) si le plugin exporte SetHost
+	if symSetHost, err := plug.Lookup("SetHost"); err == nil {
+		setHost, ok := symSetHost.(func(map[string]any))
+		if !ok {
+			return pluginLoadedMsg{model: nil, err: fmt.Errorf("signature SetHost invalide (attendu func(map[string]any))")}
+		}
+		store, err := openPluginStore(name, baseDir)
+		if err != nil {
+			return pluginLoadedMsg{model: nil, err: fmt.Errorf("erreur stockage plugin: %v", err)}
+		}
+		setHost(hostAPI(store, args))
+	}
 
-	return tea.Batch(cmds...)
+	// Créer le modèle
+	tuiModel := newTUI()
+	return pluginLoadedMsg{model: tuiModel, err: nil}
 }
 
-// Ajoute un alias dans ~/.Plugin/.pluginbashrc
-func addAliasToPluginBashrc(filename, pluginDir string) error {
-	pluginFile := filepath.Join(filepath.Dir(pluginDir), ".pluginbashrc")
-
-	aliasLine := fmt.Sprintf("alias %s='%s/Chargeur %s/%s'\n", strings.TrimSuffix(filename, filepath.Ext(filename)), filepath.Dir(pluginDir), pluginDir, filename)
+// Signaler dans les logs les alias refusés ou masquant une commande (d'un plugin, ou tous si filename est vide).
+// Retourne true si l'alias du plugin a été refusé.
+func (m *model) logAliasConflicts(filename string) bool {
+	conflicts, err := aliasConflicts(m.pluginDir)
+	if err != nil {
+		m.addLog(fmt.Sprintf("⚠️ Vérification des alias: %v", err))
+		return false
+	}
 
-	// Lire le contenu existant
-	content, _ := os.ReadFile(pluginFile)
-	if strings.Contains(string(content), aliasLine) {
-		return nil // alias déjà présent
+	refused := false
+	for _, conflict := range conflicts {
+		if filename != "" && conflict.filename != filename {
+			continue
+		}
+		if conflict.refused {
+			m.addLog(fmt.Sprintf("⛔ %s (renommer : n)", conflict))
+			refused = true
+		} else {
+			m.addLog(fmt.Sprintf("⚠️ %s", conflict))
+		}
 	}
+	return refused
+}
 
-	// Ajouter la ligne
-	f, err := os.OpenFile(pluginFile, os.O_APPEND|os.O_WRONLY, 0644)
+// Demander le nom de l'alias d'un plugin installé
+func (m model) editAlias(filename string) (tea.Model, tea.Cmd) {
+	overrides, err := loadAliasOverrides(filepath.Dir(m.pluginDir))
 	if err != nil {
-		return err
+		m.addLog(fmt.Sprintf("❌ Alias de %s: %v", filename, err))
+		return m, nil
 	}
-	defer f.Close()
 
-	_, err = f.WriteString(aliasLine)
-	return err
+	m.dialog = &dialog{
+		title: "Alias de " + filename,
+		lines: []string{fmt.Sprintf("Nom de l'alias (vide = %s) :", pluginName(filename))},
+		input: &dialogInput{
+			value: overrides.Aliases[filename],
+			submit: func(m model, value string) (tea.Model, tea.Cmd) {
+				return m.renameAlias(filename, strings.TrimSpace(value))
+			},
+		},
+		options: []dialogOption{
+			m.cancelOption(),
+		},
+	}
+	return m, nil
 }
 
-// Supprime l’alias correspondant à un fichier
-func removeAliasFromPluginBashrc(filename, pluginDir string) error {
-	pluginFile := filepath.Join(filepath.Dir(pluginDir), ".pluginbashrc")
-
-	aliasPrefix := fmt.Sprintf("alias %s='%s/Chargeur %s/%s'", strings.TrimSuffix(filename, filepath.Ext(filename)), filepath.Dir(pluginDir), pluginDir, filename)
+// Enregistrer le nom de l'alias d'un plugin et régénérer les fichiers d'alias
+func (m model) renameAlias(filename string, name string) (tea.Model, tea.Cmd) {
+	if err := setAliasOverride(filename, name, filepath.Dir(m.pluginDir)); err != nil {
+		m.addLog(fmt.Sprintf("❌ Alias de %s: %v", filename, err))
+		return m, nil
+	}
 
-	content, err := os.ReadFile(pluginFile)
+	plans, err := regenerateAliases(m.pluginDir)
 	if err != nil {
-		return err
+		m.addLog(fmt.Sprintf("❌ Régénération des alias: %v", err))
+		return m, nil
 	}
-
-	lines := strings.Split(string(content), "\n")
-	var newLines []string
-	for _, line := range lines {
-		if !strings.HasPrefix(line, aliasPrefix) {
-			newLines = append(newLines, line)
+	if !m.logAliasConflicts(filename) {
+		for _, plan := range plans {
+			m.addLog(fmt.Sprintf("🔗 Alias %s régénérés (%s)", plan.shell, plan.summary()))
 		}
 	}
-
-	return os.WriteFile(pluginFile, []byte(strings.Join(newLines, "\n")), 0644)
+	m.resetDetails()
+	return m, m.scheduleDetails()
 }
 
 func TitledBorder(activePanel string, title string, width int) lipgloss.Border {
@@ -451,7 +643,7 @@ func TitledBorder(activePanel string, title string, width int) lipgloss.Border {
 	}
 
 	return lipgloss.Border{
-		Top:         "─" + "[" + activePanel + "]" + "─" + NameInterface + strings.Repeat("─", max(0, width-len(title)-4)),
+		Top:         "─" + "[" + activePanel + "]" + "─" + NameInterface + strings.Repeat("─", max(0, width-lipgloss.Width(title)-4)),
 		Bottom:      strings.Repeat("─", width-2),
 		Left:        "│",
 		Right:       "│",
@@ -462,6 +654,48 @@ func TitledBorder(activePanel string, title string, width int) lipgloss.Border {
 	}
 }
 
+// Taille transmise au plugin selon le mode d'affichage
+func (m model) pluginWindowSize() tea.WindowSizeMsg {
+	if m.fullScreen {
+		return tea.WindowSizeMsg{Width: m.width, Height: m.height}
+	}
+	right := m.layout().right
+	return tea.WindowSizeMsg{Width: right.w, Height: right.h}
+}
+
+// Basculer le plugin actif entre le panel de droite et le plein écran
+func (m model) toggleFullScreen() (tea.Model, tea.Cmd) {
+	m.fullScreen = !m.fullScreen
+	if m.fullScreen {
+		m.activePanel = 3
+		m.addLog(fmt.Sprintf("🖥️ %s en plein écran (%s pour revenir)", m.runningTUI, m.keys.keyLabel(actFullScreen)))
+	} else {
+		m.addLog(fmt.Sprintf("🖥️ %s de retour dans le panel", m.runningTUI))
+	}
+
+	// Informer le plugin de sa nouvelle taille
+	newModel, cmd := m.embeddedTUI.Update(m.pluginWindowSize())
+	m.embeddedTUI = newModel
+	return m, cmd
+}
+
+// Lignes du panel 1 visibles dans une hauteur donnée, le curseur centré
+func (m model) installWindow(height int) (int, int) {
+	startIdx := 0
+	endIdx := len(m.displayLines)
+
+	if len(m.displayLines) > height {
+		// Centrer le curseur dans la vue visible
+		startIdx = max(0, m.cursor-height/2)
+		endIdx = startIdx + height
+		if endIdx > len(m.displayLines) {
+			endIdx = len(m.displayLines)
+			startIdx = max(0, endIdx-height)
+		}
+	}
+	return startIdx, endIdx
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -473,6 +707,86 @@ func max(a, b int) int {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	// Redimensionnement : le plugin reçoit la taille de sa zone, pas celle du terminal
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = msg.Width
+		m.height = msg.Height
+		if m.embeddedTUI != nil {
+			m.embeddedTUI, cmd = m.embeddedTUI.Update(m.pluginWindowSize())
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	// Messages de l'hôte jamais transmis au plugin actif
+	switch msg := msg.(type) {
+	case devTickMsg:
+		return m.handleDevTick()
+	case devBuildMsg:
+		return m.handleDevBuild(msg)
+	case detailsTickMsg:
+		return m.handleDetailsTick(msg)
+	case detailsLoadedMsg:
+		return m.handleDetailsLoaded(msg)
+	case pluginLoadedMsg:
+		if msg.dev {
+			return m.handleDevPluginLoaded(msg)
+		}
+		if msg.err != nil {
+			m.addLog(fmt.Sprintf("❌ Erreur chargement plugin: %v", msg.err))
+			m.runningTUI = ""
+			m.activePanel = 0
+			return m, nil
+		}
+		m.embeddedTUI = msg.model
+		m.addLog(fmt.Sprintf("✅ Plugin %s chargé avec succès", m.runningTUI))
+		initCmd := m.embeddedTUI.Init()
+		// Transmettre la taille de sa zone d'affichage au plugin
+		m.embeddedTUI, cmd = m.embeddedTUI.Update(m.pluginWindowSize())
+		return m, tea.Batch(initCmd, cmd)
+	}
+
+	// Boîte de dialogue ouverte : elle reçoit toutes les touches
+	if msg, ok := msg.(tea.KeyMsg); ok && m.dialog != nil {
+		return m.dialog.handleKey(m, msg)
+	}
+
+	// Écran d'aide : il se ferme avec sa touche ou Échap, défile avec up/down, les autres touches sont ignorées
+	if msg, ok := msg.(tea.KeyMsg); ok && m.help != nil {
+		switch {
+		case msg.String() == quitKey:
+			return m, tea.Quit
+		case msg.String() == "esc" || m.keys.matches(msg, actHelp):
+			m.help = nil
+		case m.keys.matches(msg, actUp):
+			m.scrollHelp(-1)
+		case m.keys.matches(msg, actDown):
+			m.scrollHelp(1)
+		}
+		return m, nil
+	}
+
+	// Saisie de la recherche : elle reçoit toutes les touches
+	if msg, ok := msg.(tea.KeyMsg); ok && m.searching {
+		return m.handleSearchKey(msg)
+	}
+
+	// Souris : traitée par l'hôte, transmise au plugin en coordonnées locales
+	if msg, ok := msg.(tea.MouseMsg); ok {
+		return m.handleMouse(msg)
+	}
+
+	// Aide, sauf dans le panel du plugin (la touche lui revient)
+	if msg, ok := msg.(tea.KeyMsg); ok && m.keys.matches(msg, actHelp) && m.activePanel != 3 {
+		m.openHelp()
+		return m, nil
+	}
+
+	// Touche réservée : plein écran du plugin actif (jamais transmise au plugin)
+	if msg, ok := msg.(tea.KeyMsg); ok && m.keys.matches(msg, actFullScreen) && m.embeddedTUI != nil {
+		return m.toggleFullScreen()
+	}
+
 	// Si plugin actif : PROPAGER TOUS les messages au plugin
 	if m.embeddedTUI != nil && m.activePanel == 3 {
 		// Propager d'abord
@@ -494,6 +808,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.embeddedTUI = nil
 				m.embeddedPluginID = ""
 				m.runningTUI = ""
+				m.fullScreen = false
 				m.activePanel = 1
 				// ne pas propager plus loin
 				return m, nil
@@ -506,13 +821,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Quitter avec 'q' ou Ctrl+C
-		if msg.String() == "q" || msg.String() == "ctrl+c" {
+		action := m.keys.action(m.activePanel, msg)
+
+		// Quitter ("q" par défaut, Ctrl+C toujours)
+		if action == actQuit || msg.String() == quitKey {
 			return m, tea.Quit
 		}
 
-		// Changer de panel avec Tab
-		if msg.String() == "tab" && !m.loading && !m.processing {
+		// Changer de panel (Tab par défaut)
+		if action == actNextPanel && !m.loading && !m.processing {
 			if m.embeddedTUI != nil {
 				m.activePanel = (m.activePanel + 1) % 4
 			} else {
@@ -523,42 +840,78 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Changement de panel
-		switch msg.String() {
-		case "0":
+		switch action {
+		case actPanelHome:
 			m.activePanel = 0
 			return m, nil
-		case "1":
+		case actPanelRepos:
 			m.activePanel = 1
 			return m, nil
-		case "2":
+		case actPanelLogs:
 			m.activePanel = 2
 			return m, nil
 		}
 
+		// Redimensionner la colonne gauche ("<" / ">") ; le plugin intégré suit la nouvelle taille
+		if action == actShrinkLeft || action == actGrowLeft {
+			delta := splitStep
+			if action == actShrinkLeft {
+				delta = -splitStep
+			}
+			m = m.resizeSplit(delta)
+			if m.embeddedTUI != nil {
+				m.embeddedTUI, cmd = m.embeddedTUI.Update(m.pluginWindowSize())
+			}
+			return m, cmd
+		}
+
+		// Recherche dans le panel 1 : "/" pour saisir, Échap pour effacer le filtre
+		if !m.loading && !m.processing && len(m.repos) > 0 && m.activePanel == 1 {
+			switch action {
+			case actSearch:
+				m.searching = true
+				return m, nil
+			case actClearSearch:
+				if m.searchQuery != "" {
+					m.searchQuery = ""
+					m.refreshDisplayLines()
+				}
+				return m, m.scheduleDetails()
+			case actView:
+				// Vue suivante : tous, installés, non installés, mises à jour, sélection
+				m.view = (m.view + 1) % viewCount
+				m.refreshDisplayLines()
+				return m, m.scheduleDetails()
+			}
+		}
+
 		// Navigation avec les flèches (Pannel Installation)
 		if !m.loading && !m.processing && len(m.displayLines) > 0 && m.activePanel == 1 {
-			switch msg.String() {
-			case "up", "k":
+			switch action {
+			case actUp:
 				if m.cursor > 0 {
 					m.cursor--
 				}
-			case "down", "j":
+			case actDown:
 				if m.cursor < len(m.displayLines)-1 {
 					m.cursor++
 				}
-			case "enter":
+			case actConfirm:
 				// Valider la selection
 				line := m.displayLines[m.cursor]
 				if !line.isHeader {
-					// Valider les opérations
+					// Confirmer les opérations (installation, mise à jour ou suppression selon l'état de chaque plugin)
 					if len(m.selected) > 0 {
-						m.processing = true
-						m.statusMsg = fmt.Sprintf("Traitement de %d Plugin(s)...", len(m.selected))
-						m.addLog(fmt.Sprintf("🚀 Démarrage du traitement de %d Plugin(s)", len(m.selected)))
-						return m, processSelectedFiles(m)
+						return m.confirmBatch(batchAll)
 					}
 				}
-			case " ":
+			case actInstall:
+				// Installer ou mettre à jour la sélection (ou le plugin sous le curseur)
+				return m.confirmBatch(batchInstall)
+			case actRemove:
+				// Supprimer la sélection (ou le plugin sous le curseur)
+				return m.confirmBatch(batchRemove)
+			case actToggle:
 				// Sélectionner/désélectionner ou Replier/déplier fichier/repositorie actuel
 				line := m.displayLines[m.cursor]
 				if !line.isHeader {
@@ -568,6 +921,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					} else {
 						m.selected[key] = true
 					}
+					if m.view == viewSelected {
+						m.refreshDisplayLines()
+					}
 				} else if line.isHeader {
 					m.repos[line.repoIdx].Collapsed = !m.repos[line.repoIdx].Collapsed
 					m.buildDisplayLines()
@@ -576,48 +932,67 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.cursor = len(m.displayLines) - 1
 					}
 				}
-			case "e":
+			case actRun:
 				// Exécuter le TUI du fichier sélectionné
 				line := m.displayLines[m.cursor]
 				if !line.isHeader {
-					file := m.repos[line.repoIdx].Files[line.fileIdx]
-					if m.localFiles[file.Name] {
-						m.runningTUI = file.Name
-						m.addLog(fmt.Sprintf("▶️ Chargement de %s", file.Name))
-						m.activePanel = 3
-						return m, loadPlugin(file.Name, m.pluginDir)
+					repo := m.repos[line.repoIdx]
+					file := repo.Files[line.fileIdx]
+					if m.localFiles[localKey(repo.Name, file.Name)] {
+						return m.runPlugin(file.Name)
 					} else {
 						m.addLog(fmt.Sprintf("⚠️ %s n'est pas téléchargé", file.Name))
 					}
 				}
-			case "c":
+			case actClearSelection:
 				// Annuler toutes les sélections
 				m.selected = make(map[string]bool)
+			case actAliasName:
+				// Choisir le nom de l'alias du plugin sélectionné
+				line := m.displayLines[m.cursor]
+				if !line.isHeader {
+					repo := m.repos[line.repoIdx]
+					file := repo.Files[line.fileIdx]
+					if m.localFiles[localKey(repo.Name, file.Name)] {
+						return m.editAlias(file.Name)
+					}
+					m.addLog(fmt.Sprintf("⚠️ %s n'est pas téléchargé", file.Name))
+				}
+			case actRegenAliases:
+				// Reconstruire les fichiers d'alias depuis les plugins installés
+				plans, err := regenerateAliases(m.pluginDir)
+				for _, plan := range plans {
+					m.addLog(fmt.Sprintf("🔗 Alias %s régénérés (%s)", plan.shell, plan.summary()))
+				}
+				if err != nil {
+					m.addLog(fmt.Sprintf("❌ Régénération des alias: %v", err))
+				}
+				m.logAliasConflicts("")
 			}
 		}
 
 		// === Scroll du panneau de logs (panel 2) ===
 		if m.activePanel == 2 && len(m.logs) > 0 {
-			switch msg.String() {
-			case "up", "k":
+			switch action {
+			case actUp:
 				if m.scrollOffset > 0 {
 					m.scrollOffset--
 				}
-			case "down", "j":
-				if m.scrollOffset < len(m.logs)-(m.height-8) {
+			case actDown:
+				if m.scrollOffset < m.maxLogScroll() {
 					m.scrollOffset++
 				}
-			case "ctrl+up": // aller tout en haut
+			case actTop: // aller tout en haut
 				m.scrollOffset = 0
-			case "ctrl+down": // aller tout en bas
-				m.scrollOffset = len(m.logs) - (m.height - 8)
+			case actBottom: // aller tout en bas
+				m.scrollOffset = m.maxLogScroll()
 			}
 		}
 
-	case tea.WindowSizeMsg:
-		// Capturer la taille de la fenêtre
-		m.width = msg.Width
-		m.height = msg.Height
+		// Détails du plugin sous le curseur, chargés si le curseur s'y arrête
+		if m.activePanel == 1 && m.embeddedTUI == nil {
+			return m, m.scheduleDetails()
+		}
 
 	case filesLoadedMsg:
 		m.loading = false
@@ -631,42 +1006,83 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				totalFiles += len(repo.Files)
 			}
 			m.addLog(fmt.Sprintf("✅ %d Repository(s) chargé(s) avec %d Plugin(s)", len(msg.repos), totalFiles))
-
-			// Vérifier quels fichiers existent localement
-			m.localFiles = make(map[string]bool)
+			for _, err := range msg.repoErrors {
+				m.addLog(fmt.Sprintf("⚠️ Repository ignoré: %v", err))
+			}
 			for _, repo := range m.repos {
-				for _, file := range repo.Files {
-					filePath := filepath.Join(m.pluginDir, file.Name)
-					if _, err := os.Stat(filePath); err == nil {
-						m.localFiles[file.Name] = true
-					}
+				switch {
+				case repo.KeyError != nil:
+					m.addLog(fmt.Sprintf("⛔ %s : %v, installation bloquée", repo.Name, repo.KeyError))
+				case repo.Blocked:
+					m.addLog(fmt.Sprintf("⛔ %s non signé : installation bloquée", repo.Name))
 				}
 			}
+
+			if state, err := loadInstalled(filepath.Dir(m.pluginDir)); err == nil {
+				m.installed = state
+			}
+			// Vérifier quels fichiers existent localement
+			m.localFiles = localPluginFiles(m.repos, m.pluginDir, m.installed)
+			m.loadDescriptions()
+			m.resetDetails()
 			// Construire la liste d'affichage
 			m.buildDisplayLines()
 		}
 
 	case operationCompleteMsg:
+		// Les opérations d'un lot tournent en parallèle : le lot est terminé au dernier résultat
+		var done tea.Cmd
+		if m.pending > 0 {
+			m.pending--
+			if m.pending == 0 {
+				done = func() tea.Msg { return allOperationsCompleteMsg{} }
+			}
+		}
+
+		// Chemin, version et alias du plugin changent
+		m.resetDetails()
 		if msg.err != nil {
 			m.statusMsg = fmt.Sprintf("❌ Erreur %s: %v", msg.filename, msg.err)
 			m.addLog(fmt.Sprintf("❌ Erreur %s %s: %v", msg.operation, msg.filename, msg.err))
+			return m, done
 		} else {
+			// installed.json vient d'être écrit par l'opération : les états (vues, mises à jour) en dépendent
+			if state, err := loadInstalled(filepath.Dir(m.pluginDir)); err == nil {
+				m.installed = state
+			}
 			if msg.operation == "download" {
 				m.statusMsg = fmt.Sprintf("✅ %s téléchargé!", msg.filename)
-				m.localFiles[msg.filename] = true
+				m.localFiles[localKey(msg.repo, msg.filename)] = true
+				if manifest, err := readLocalManifest(msg.filename, m.pluginDir); err == nil && manifest != nil {
+					m.descriptions[msg.filename] = manifest.Description
+				}
 				m.addLog(fmt.Sprintf("⬇️ %s téléchargé avec succès", msg.filename))
+				if msg.verified {
+					m.addLog(fmt.Sprintf("🔒 Signature de %s vérifiée", msg.filename))
+				} else if msg.unsigned {
+					m.addLog(fmt.Sprintf("⚠️ %s provient d'un dépôt non signé", msg.filename))
+				}
 				// ✅ Ajouter l'alias automatiquement
-				if err := addAliasToPluginBashrc(msg.filename, m.pluginDir); err != nil {
+				if _, err := regenerateAliases(m.pluginDir); err != nil {
 					m.addLog(fmt.Sprintf("⚠️ Impossible d'ajouter l'alias pour %s: %v", msg.filename, err))
-				} else {
+				} else if !m.logAliasConflicts(msg.filename) {
 					m.addLog(fmt.Sprintf("🔗 Alias ajouté pour %s", msg.filename))
 				}
 			} else {
 				m.statusMsg = fmt.Sprintf("🗑️ %s supprimé!", msg.filename)
-				delete(m.localFiles, msg.filename)
+				for _, repo := range m.repos {
+					delete(m.localFiles, localKey(repo.Name, msg.filename))
+				}
 				m.addLog(fmt.Sprintf("🗑️ %s supprimé avec succès", msg.filename))
+				if msg.purged {
+					m.addLog(fmt.Sprintf("🧹 Données de %s purgées", msg.filename))
+				}
+				// Un plugin réinstallé redemandera le consentement
+				if err := revokePermission(msg.filename, filepath.Dir(m.pluginDir)); err != nil {
+					m.addLog(fmt.Sprintf("⚠️ Impossible de retirer les permissions de %s: %v", msg.filename, err))
+				}
 				// ✅ Supprimer l'alias automatiquement
-				if err := removeAliasFromPluginBashrc(msg.filename, m.pluginDir); err != nil {
+				if _, err := regenerateAliases(m.pluginDir); err != nil {
 					m.addLog(fmt.Sprintf("⚠️ Impossible de retirer l'alias pour %s: %v", msg.filename, err))
 				} else {
 					m.addLog(fmt.Sprintf("🔗 Alias supprimé pour %s", msg.filename))
@@ -675,16 +1091,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.runningTUI == msg.filename {
 					m.embeddedTUI = nil
 					m.runningTUI = ""
+					m.fullScreen = false
 				}
 			}
-			return m, tea.Tick(time.Second*3, func(t time.Time) tea.Msg {
+			return m, tea.Batch(done, tea.Tick(time.Second*3, func(t time.Time) tea.Msg {
 				return tickMsg(t)
-			})
+			}))
 		}
 
 	case allOperationsCompleteMsg:
 		m.processing = false
 		m.selected = make(map[string]bool)
+		// Les plugins traités changent de vue
+		if state, err := loadInstalled(filepath.Dir(m.pluginDir)); err == nil {
+			m.installed = state
+		}
+		m.refreshDisplayLines()
 		m.statusMsg = "✅ Toutes les opérations terminées!"
 		m.addLog("✅ Toutes les opérations terminées!")
 		// Effacer le message après 3 secondes
@@ -692,17 +1114,6 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return tickMsg(t)
 		})
 
-	case pluginLoadedMsg:
-		if msg.err != nil {
-			m.addLog(fmt.Sprintf("❌ Erreur chargement plugin: %v", msg.err))
-			m.runningTUI = ""
-			m.activePanel = 0
-		} else {
-			m.embeddedTUI = msg.model
-			m.addLog(fmt.Sprintf("✅ Plugin %s chargé avec succès", m.runningTUI))
-			return m, m.embeddedTUI.Init()
-		}
-
 	case tickMsg:
 		if m.loading || m.processing {
 			m.spinnerFrame = (m.spinnerFrame + 1) % len(spinnerFrames)
@@ -724,14 +1135,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // Affichage
 func (m model) View() string {
-	// Vérifier la taille minimale de la fenêtre
-	const minWidth = 71
-	const minHeight = 37
+	// Plugin en plein écran : ni panels gauches ni barre de statut
+	if m.fullScreen && m.embeddedTUI != nil {
+		return lipgloss.NewStyle().
+			MaxWidth(m.width).
+			MaxHeight(m.height).
+			Render(m.embeddedTUI.View())
+	}
 
-	if m.width < minWidth || m.height < minHeight {
+	// Vérifier la taille minimale de la fenêtre
+	if m.width < tinyWidth || m.height < tinyHeight {
 		// Message d'avertissement stylisé
-		warningStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("11")).
+		warningStyle := m.theme.style().
+			Foreground(m.theme.Warning).
 			Bold(true).
 			Padding(1, 2)
 
@@ -740,118 +1156,80 @@ func (m model) View() string {
 				"Taille actuelle : %dx%d\n"+
 				"Taille minimale : %dx%d\n\n"+
 				"Veuillez agrandir votre terminal.",
-			m.width, m.height, minWidth, minHeight,
+			m.width, m.height, tinyWidth, tinyHeight,
 		)
 
 		return warningStyle.Render(message)
 	}
 
-	// Largeur pour les panels gauches
-	leftPanelWidth := 35
-	// Largeur pour le panel droit (TUI)
-	rightPanelWidth := m.width - leftPanelWidth - 4
-
-	// Calculer la hauteur disponible
+	// Mise en page selon la taille du terminal (une seule colonne si elle est étroite)
+	l := m.layout()
 	availableHeight := m.height - 1
 
-	// Hauteur fixe pour chaque panel gauche
-	PresentHeight := 1
-	InstallHeight := 0
-	LogHeight := 0
-
-	// Hauteur du panel de droite
-	rightPanelHeight := 0
-
-	if m.activePanel == 0 {
-		InstallHeight = int(float64(availableHeight) * 0.4)
-		LogHeight = availableHeight - PresentHeight - InstallHeight - 6
-	} else if m.activePanel == 1 {
-		InstallHeight = int(float64(availableHeight) * 0.8)
-		LogHeight = availableHeight - PresentHeight - InstallHeight - 6
-	} else if m.activePanel == 2 {
-		InstallHeight = int(float64(availableHeight) * 0.4)
-		LogHeight = availableHeight - PresentHeight - InstallHeight - 6
-	} else if m.activePanel == 3 {
-		InstallHeight = int(float64(availableHeight) * 0.8)
-		LogHeight = availableHeight - PresentHeight - InstallHeight - 6
+	// Taille des panels ; en une colonne, le panel actif occupe tout le cadre
+	leftPanelWidth, rightPanelWidth := l.install.w, l.right.w
+	PresentHeight, InstallHeight, LogHeight := l.present.h, l.install.h, l.log.h
+	rightPanelHeight := l.right.h
+	if l.narrow {
+		frame := l.install
+		if !frame.visible() {
+			frame = l.right
+		}
+		leftPanelWidth, rightPanelWidth = frame.w, frame.w
+		InstallHeight, rightPanelHeight = frame.h, frame.h
 	}
 
-	rightPanelHeight = availableHeight - 2
-
-	// Styles pour les panels gauches
-	PresentBoxStyle := lipgloss.NewStyle().
+	// Styles des panels : bordure de couleur accentuée pour le panel actif
+	t := m.theme
+	PannelPresentStyle := t.style().
 		Border(TitledBorder("0", "", leftPanelWidth)).
-		BorderForeground(lipgloss.Color("86")).
+		BorderForeground(t.border(m.activePanel == 0)).
 		Padding(0, 2).
 		Width(leftPanelWidth).
 		Height(PresentHeight)
 
-	PresentBoxInactiveStyle := lipgloss.NewStyle().
-		Border(TitledBorder("0", "", leftPanelWidth)).
-		BorderForeground(lipgloss.Color("240")).
-		Padding(0, 2).
-		Width(leftPanelWidth).
-		Height(PresentHeight)
-
-	InstallBoxStyle := lipgloss.NewStyle().
-		Border(TitledBorder("1", "Repositories", leftPanelWidth)).
-		BorderForeground(lipgloss.Color("86")).
-		Width(leftPanelWidth).
-		Height(InstallHeight)
-
-	InstallBoxInactiveStyle := lipgloss.NewStyle().
-		Border(TitledBorder("1", "Repositories", leftPanelWidth)).
-		BorderForeground(lipgloss.Color("240")).
+	PannelInstallStyle := t.style().
+		Border(TitledBorder("1", m.viewTitle(), leftPanelWidth)).
+		BorderForeground(t.border(m.activePanel == 1)).
 		Width(leftPanelWidth).
 		Height(InstallHeight)
 
-	LogBoxStyle := lipgloss.NewStyle().
+	PannelLogStyle := t.style().
 		Border(TitledBorder("2", "Log", leftPanelWidth)).
-		BorderForeground(lipgloss.Color("86")).
+		BorderForeground(t.border(m.activePanel == 2)).
 		Width(leftPanelWidth).
 		Height(LogHeight)
 
-	LogBoxInactiveStyle := lipgloss.NewStyle().
-		Border(TitledBorder("2", "Log", leftPanelWidth)).
-		BorderForeground(lipgloss.Color("240")).
-		Width(leftPanelWidth).
-		Height(LogHeight)
-
-	// Style pour le panel de droite
-	rightBoxStyle := lipgloss.NewStyle().
+	PannelRightStyle := t.style().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("86")).
-		Width(rightPanelWidth).
-		Height(rightPanelHeight)
-
-	rightBoxInactiveStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(t.border(m.activePanel == 3)).
 		Width(rightPanelWidth).
 		Height(rightPanelHeight)
 
 	// Style de la barre de statut
-	statusStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("15")).
+	statusStyle := t.style().
+		Foreground(t.Text).
 		Width(m.width - 2).
 		Align(lipgloss.Left)
 
 	// Styles pour les fichiers
-	selectedStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("240")).
-		Foreground(lipgloss.Color("15"))
-
-	notDownloadedStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("39"))
-
-	downloadedStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("2"))
-
-	toDeleteStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("1"))
-
-	repoHeaderStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("15"))
+	selectedStyle := t.selected()
+	notDownloadedStyle := t.style().Foreground(t.Available)
+	downloadedStyle := t.style().Foreground(t.Installed)
+	toDeleteStyle := t.style().Foreground(t.Remove)
+	repoHeaderStyle := t.style().Foreground(t.Text)
+	unsignedRepoStyle := t.style().Foreground(t.Warning)
+	blockedRepoStyle := t.style().
+		Foreground(t.Remove).
+		Strikethrough(true)
+
+	searchStyle := t.style().Foreground(t.Accent)
+
+	// Onglets du mode une colonne
+	tabActiveStyle := t.style().
+		Bold(true).
+		Foreground(t.Accent)
+	tabInactiveStyle := t.style().Foreground(t.Muted)
 
 	// === PANEL GAUCHE - Presentation ===
 	var PannelPresent strings.Builder
@@ -870,26 +1248,22 @@ func (m model) View() string {
 		maxLengthWidht := leftPanelWidth - 6
 		maxLengthHeight := InstallHeight - 0
 
-		// Calculer la plage visible en fonction du curseur
-		startIdx := 0
-		endIdx := len(m.displayLines)
-
-		if len(m.displayLines) > maxLengthHeight {
-			// Centrer le curseur dans la vue visible
-			startIdx = m.cursor - maxLengthHeight/2
-			if startIdx < 0 {
-				startIdx = 0
-			}
-			endIdx = startIdx + maxLengthHeight
-			if endIdx > len(m.displayLines) {
-				endIdx = len(m.displayLines)
-				startIdx = endIdx - maxLengthHeight
-				if startIdx < 0 {
-					startIdx = 0
-				}
+		// Ligne de recherche en tête du panel
+		if m.searching || m.searchQuery != "" {
+			cursor := ""
+			if m.searching {
+				cursor = "█"
 			}
+			PannelInstall.WriteString(searchStyle.Render("/ "+m.searchQuery+cursor) + "\n")
+			maxLengthHeight--
+		}
+		if len(m.displayLines) == 0 {
+			PannelInstall.WriteString("\n  Aucun plugin ne correspond")
 		}
 
+		// Calculer la plage visible en fonction du curseur
+		startIdx, endIdx := m.installWindow(maxLengthHeight)
+
 		for i := startIdx; i < endIdx; i++ {
 			line := m.displayLines[i]
 
@@ -906,47 +1280,56 @@ func (m model) View() string {
 				if m.repos[line.repoIdx].Collapsed {
 					indicator = "▶"
 				}
-				headerText := fmt.Sprintf("%s %s", indicator, line.text)
-				if len(headerText) > maxLengthWidht {
-					headerText = headerText[:maxLengthWidht-3] + "..."
+
+				// Statut de confiance du dépôt
+				repo := m.repos[line.repoIdx]
+				trustMark, headerStyle := "✓", repoHeaderStyle
+				if repo.Blocked {
+					trustMark, headerStyle = "⨯", blockedRepoStyle
+				} else if repo.PublicKey == "" {
+					trustMark, headerStyle = "!", unsignedRepoStyle
 				}
 
+				headerText := truncateText(fmt.Sprintf("%s %s %s", indicator, trustMark, line.text), maxLengthWidht)
+
 				if i == m.cursor && m.activePanel == 1 {
-					paddedLine := headerText + strings.Repeat(" ", leftPanelWidth-len(headerText)-2)
+					paddedLine := padText(headerText, leftPanelWidth)
 					PannelInstall.WriteString(selectedStyle.Render(paddedLine) + newline)
 				} else {
-					PannelInstall.WriteString(repoHeaderStyle.Render(headerText) + newline)
+					PannelInstall.WriteString(headerStyle.Render(headerText) + newline)
 				}
 			} else {
 				// Afficher un fichier
 				file := m.repos[line.repoIdx].Files[line.fileIdx]
 				key := fmt.Sprintf("%d:%d", line.repoIdx, line.fileIdx)
+				local := m.localFiles[localKey(m.repos[line.repoIdx].Name, file.Name)]
 
 				var textStyle lipgloss.Style
-				if m.selected[key] && m.localFiles[file.Name] {
+				if m.selected[key] && local {
 					textStyle = toDeleteStyle
-				} else if m.localFiles[file.Name] || (m.selected[key] && !m.localFiles[file.Name]) {
+				} else if local || m.selected[key] {
 					textStyle = downloadedStyle
 				} else {
 					textStyle = notDownloadedStyle
 				}
 
+				// Case de sélection (cliquable), puis marque du plugin en cours d'exécution
 				prefix := "  "
+				if m.selected[key] {
+					prefix = selectionMark + " "
+				}
 				if file.Name == m.runningTUI {
-					prefix = "   → "
+					prefix += " → "
 				}
 
-				displayText := prefix + file.Name
-				if len(displayText) > maxLengthWidht {
-					displayText = displayText[:maxLengthWidht-3] + "..."
-				}
+				displayText, matches := matchLine(prefix, file.Name, line.matches, maxLengthWidht)
 
 				if i == m.cursor && m.activePanel == 1 {
 					selectedWithColor := selectedStyle.Foreground(textStyle.GetForeground())
-					paddedLine := displayText + strings.Repeat(" ", leftPanelWidth-len(displayText)-4)
-					PannelInstall.WriteString(selectedWithColor.Render(paddedLine) + newline)
+					paddedLine := padText(displayText, leftPanelWidth)
+					PannelInstall.WriteString(highlightRunes(paddedLine, matches, selectedWithColor, selectedWithColor.Bold(true).Underline(true)) + newline)
 				} else {
-					PannelInstall.WriteString(textStyle.Render(displayText) + newline)
+					PannelInstall.WriteString(highlightRunes(displayText, matches, textStyle, textStyle.Bold(true).Underline(true)) + newline)
 				}
 			}
 		}
@@ -979,11 +1362,7 @@ func (m model) View() string {
 				newline = "\n"
 			}
 
-			log := m.logs[i]
-			if len(log) > maxLengthWidht {
-				log = log[:maxLengthWidht-3] + "..."
-			}
-			PannelLog.WriteString(log + newline)
+			PannelLog.WriteString(truncateText(m.logs[i], maxLengthWidht) + newline)
 		}
 	}
 
@@ -994,15 +1373,7 @@ func (m model) View() string {
 		PannelDroite.WriteString(m.embeddedTUI.View())
 	} else if m.activePanel == 2 {
 		if len(m.logs) != 0 {
-			maxLogs := 0
-			if m.scrollOffset == 0 {
-				maxLogs = rightPanelHeight - 3
-			} else {
-				maxLogs = rightPanelHeight - 5
-			}
-			if maxLogs < 1 {
-				maxLogs = 1
-			}
+			maxLogs := m.logRows(m.scrollOffset > 0)
 
 			// Calculer la fenêtre visible avec le scroll
 			visibleStart := len(m.logs) - maxLogs - m.scrollOffset
@@ -1017,11 +1388,7 @@ func (m model) View() string {
 			maxLengthWidht := rightPanelWidth - 0
 
 			for i := visibleEnd - 1; i >= visibleStart; i-- {
-				log := m.logs[i]
-				if len(log) > maxLengthWidht {
-					log = log[:maxLengthWidht-3] + "..."
-				}
-				PannelDroite.WriteString(log + "\n")
+				PannelDroite.WriteString(truncateText(m.logs[i], maxLengthWidht) + "\n")
 			}
 
 			// Indicateur visuel du scroll
@@ -1032,16 +1399,30 @@ func (m model) View() string {
 			PannelDroite.WriteString("\n  Aucun log à afficher...")
 		}
 
+	} else if repo, file, ok := m.currentPlugin(); ok && m.activePanel == 1 {
+		PannelDroite.WriteString(m.detailsView(repo, file, rightPanelWidth, rightPanelHeight))
 	} else if m.activePanel == 1 {
 		PannelDroite.WriteString("\n  Exécution TUI\n\n")
 		PannelDroite.WriteString("  Sélectionnez un fichier\n")
 		PannelDroite.WriteString("  téléchargé et appuyez\n")
-		PannelDroite.WriteString("  sur 'e' pour exécuter\n")
+		PannelDroite.WriteString(fmt.Sprintf("  sur '%s' pour exécuter\n", m.keys.keyLabel(actRun)))
 		PannelDroite.WriteString("  son TUI ici.\n\n")
 		PannelDroite.WriteString("  Commandes:\n")
-		PannelDroite.WriteString("  • e: Exécuter\n")
-		PannelDroite.WriteString("  • s: Arrêter le TUI\n")
-		PannelDroite.WriteString("  • Enter: Télécharger/Supprimer")
+		for _, binding := range m.keys.panelBindings(1) {
+			PannelDroite.WriteString(fmt.Sprintf("  • %s: %s\n", m.keys.keyLabel(binding.action), binding.help))
+		}
+		PannelDroite.WriteString(fmt.Sprintf("\n  Vues (%s):\n", m.keys.keyLabel(actView)))
+		counts := m.viewCounts()
+		for view, label := range viewLabels {
+			if view == viewAll {
+				label = "Tous"
+			}
+			marker := " "
+			if view == m.view {
+				marker = "▸"
+			}
+			PannelDroite.WriteString(fmt.Sprintf("  %s %-14s %d\n", marker, label, counts[view]))
+		}
 	} else if m.activePanel == 0 {
 		NomPlugin := `
     ____     _____ _   _ ___ 
@@ -1061,47 +1442,38 @@ func (m model) View() string {
 		PannelDroite.WriteString("  https://github.com/TWilhem/Plugin/issues\n\n")
 	}
 
-	// Choisir les styles selon le panel actif
-	var PannelPresentStyle, PannelInstallStyle, PannelLogStyle, PannelRightStyle lipgloss.Style
-	if m.activePanel == 0 {
-		PannelPresentStyle = PresentBoxStyle
-		PannelInstallStyle = InstallBoxInactiveStyle
-		PannelLogStyle = LogBoxInactiveStyle
-		PannelRightStyle = rightBoxInactiveStyle
-	} else if m.activePanel == 1 {
-		PannelPresentStyle = PresentBoxInactiveStyle
-		PannelInstallStyle = InstallBoxStyle
-		PannelLogStyle = LogBoxInactiveStyle
-		PannelRightStyle = rightBoxInactiveStyle
-	} else if m.activePanel == 2 {
-		PannelPresentStyle = PresentBoxInactiveStyle
-		PannelInstallStyle = InstallBoxInactiveStyle
-		PannelLogStyle = LogBoxStyle
-		PannelRightStyle = rightBoxInactiveStyle
-	} else if m.activePanel == 3 {
-		PannelPresentStyle = PresentBoxInactiveStyle
-		PannelInstallStyle = InstallBoxInactiveStyle
-		PannelLogStyle = LogBoxInactiveStyle
-		PannelRightStyle = rightBoxStyle
-	}
+	// Contenus limités à leur cadre (un plugin ou la présentation peuvent le dépasser)
+	installContent := fitContent(PannelInstall.String(), leftPanelWidth, InstallHeight)
+	rightContent := fitContent(PannelDroite.String(), rightPanelWidth, rightPanelHeight)
 
-	// Empiler les panels gauches
-	leftPanels := lipgloss.JoinVertical(
-		lipgloss.Left,
-		PannelPresentStyle.Render(PannelPresent.String()),
-		PannelInstallStyle.Render(PannelInstall.String()),
-		PannelLogStyle.Render(PannelLog.String()),
-	)
+	var allPanels string
+	if l.narrow {
+		// Une colonne : onglets puis le panel actif
+		frame := PannelRightStyle.BorderForeground(t.Accent).Render(rightContent)
+		if m.activePanel == 1 {
+			frame = PannelInstallStyle.Render(installContent)
+		}
+		allPanels = m.renderTabs(tabActiveStyle, tabInactiveStyle) + "\n" + frame
+	} else {
+		// Empiler les panels gauches visibles
+		var leftPanels []string
+		if l.present.visible() {
+			leftPanels = append(leftPanels, PannelPresentStyle.Render(PannelPresent.String()))
+		}
+		leftPanels = append(leftPanels, PannelInstallStyle.Render(installContent))
+		if l.log.visible() {
+			leftPanels = append(leftPanels, PannelLogStyle.Render(fitContent(PannelLog.String(), leftPanelWidth, LogHeight)))
+		}
 
-	// Joindre gauche et droite
-	allPanels := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		leftPanels,
-		PannelRightStyle.Render(PannelDroite.String()),
-	)
+		// Joindre gauche et droite
+		allPanels = lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			lipgloss.JoinVertical(lipgloss.Left, leftPanels...),
+			PannelRightStyle.Render(rightContent),
+		)
+	}
 
-	panelsHeight := PresentHeight + InstallHeight + LogHeight + 7
-	spacerHeight := availableHeight - panelsHeight
+	spacerHeight := availableHeight - lipgloss.Height(allPanels)
 	if spacerHeight < 0 {
 		spacerHeight = 0
 	}
@@ -1114,6 +1486,8 @@ func (m model) View() string {
 		statusBar.message = fmt.Sprintf("Récupération %s", spinnerFrames[m.spinnerFrame])
 	} else if m.processing {
 		statusBar.message = fmt.Sprintf("%s %s", m.statusMsg, spinnerFrames[m.spinnerFrame])
+	} else if m.searching {
+		statusBar.message = "Recherche : Entrée pour valider, Échap pour effacer"
 	} else if m.statusMsg != "" {
 		statusBar.message = m.statusMsg
 	} else if len(m.selected) > 0 {
@@ -1124,168 +1498,41 @@ func (m model) View() string {
 		statusBar.commands = m.cmdTemplate
 	}
 
-	return allPanels + spacer + "\n" + statusStyle.Render(statusBar.render(m.width))
+	view := allPanels + spacer + "\n" + statusStyle.Render(statusBar.render(m.width-2))
+	if m.help != nil {
+		view = overlayCenter(view, m.renderHelp(m.width, m.height), m.width, m.height)
+	}
+	if m.dialog != nil {
+		view = overlayCenter(view, m.dialog.render(m.theme, m.width, m.height), m.width, m.height)
+	}
+	return view
 }
 
 func main() {
-	usr, err := user.Current()
-	if err != nil {
-		fmt.Println("Impossible de récupérer l'utilisateur courant:", err)
-		return
-	}
-
-	chemin := flag.String("c", "", "Chemin du fichier à utiliser")
-	flag.Parse()
+	os.Exit(runCLI(os.Args[1:]))
+}
 
-	var baseDir string
-	if *chemin != "" {
-		// si l'utilisateur spécifie un chemin, l'utiliser tel quel (prendre sa version absolue)
-		abs, err := filepath.Abs(*chemin)
+// Lancer le panel interactif
+func runTUI(ctx *cliContext) int {
+	m := initialModel(ctx.baseDir, ctx.configPath)
+	if ctx.devPath != "" {
+		w, err := newDevWatcher(ctx.devPath, ctx.baseDir)
 		if err != nil {
-			baseDir = *chemin
-		} else {
-			baseDir = abs
-		}
-	} else {
-		// Par défaut, utiliser le dossier utilisateur (HOME)
-		baseDir = filepath.Join(usr.HomeDir, ".Plugin/")
-	}
-
-	m := initialModel(baseDir)
-	pluginDir := m.pluginDir
-	pluginFile := filepath.Join(filepath.Dir(pluginDir), ".pluginbashrc")
-	chargeurFile := filepath.Join(filepath.Dir(pluginDir), "Chargeur")
-
-	home := usr.HomeDir
-	bashrcPath := filepath.Join(home, ".bashrc")
-
-	pluginBlock := fmt.Sprintf(`# Ajout Liste Plugin
-if [ -f %s ]; then 
-    source %s
-fi`, pluginFile, pluginFile)
-
-	// Vérification des arguments
-	if len(os.Args) > 1 {
-		switch os.Args[1] {
-		case "install":
-			// --- Créer le dossier ./.Plugin/Plugin ---
-			if _, err := os.Stat(pluginDir); os.IsNotExist(err) {
-				err := os.MkdirAll(pluginDir, 0755)
-				if err != nil {
-					fmt.Printf("Erreur création du dossier %s: %s", pluginDir, err)
-					return
-				}
-				fmt.Printf("Dossier %s créé.\n", pluginDir)
-			}
-
-			// --- Télecharge le fichier ./.Plugin/Chargeur ---
-			if _, err := os.Stat(chargeurFile); os.IsNotExist(err) {
-				DownloadchargeurFile := GitHubFile{
-					Name:        "Chargeur",
-					Type:        "file",
-					DownloadURL: "https://raw.githubusercontent.com/TWilhem/Plugin/main/Chargeur",
-				}
-
-				cmd := downloadFile(DownloadchargeurFile, filepath.Dir(pluginDir))
-
-				os.Chmod(filepath.Join(filepath.Dir(pluginDir), chargeurFile), 0755)
-				msg := cmd()
-				if opMsg, ok := msg.(operationCompleteMsg); ok {
-					if opMsg.err != nil {
-						fmt.Printf("Erreur téléchargement Chargeur: %v\n", opMsg.err)
-						return
-					}
-					fmt.Printf("Fichier Chargeur téléchargé avec succès.\n")
-				}
-			}
-
-			// --- Créer le fichier ./.Plugin/.pluginbashrc ---
-			if _, err := os.Stat(pluginFile); os.IsNotExist(err) {
-				content := "# Plugin bashrc initialisé\n" + "alias Plugin=\"$HOME/.Plugin/Pannel && source ~/.bashrc\"\n"
-				err := os.WriteFile(pluginFile, []byte(content), 0644)
-				if err != nil {
-					fmt.Printf("Erreur création du fichier %s: %s", pluginFile, err)
-					return
-				}
-				fmt.Printf("Fichier %s créé.\n", pluginFile)
-			} else {
-				fmt.Printf("Fichier %s déjà existant.\n", pluginFile)
-			}
-
-			// --- Ajouter le bloc dans ~/.bashrc ---
-			content, _ := os.ReadFile(bashrcPath)
-			if !strings.Contains(string(content), pluginBlock) {
-				f, err := os.OpenFile(bashrcPath, os.O_APPEND|os.O_WRONLY, 0644)
-				if err != nil {
-					fmt.Printf("Erreur ouverture %s/.bashrc: %s\n", home, err)
-					return
-				}
-				defer f.Close()
-
-				if _, err := f.WriteString("\n" + pluginBlock + "\n"); err != nil {
-					fmt.Printf("Erreur écriture dans %s/.bashrc: %s\n", home, err)
-					return
-				}
-				fmt.Printf("Bloc plugin ajouté à %s/.bashrc\n", home)
-			} else {
-				fmt.Printf("Bloc plugin déjà présent dans %s/.bashrc\n", home)
-			}
-			return
-
-		case "uninstall":
-			// --- Supprimer le fichier .pluginbashrc ---
-			if err := os.Remove(pluginFile); err == nil {
-				fmt.Printf("Fichier %s supprimé.\n", pluginFile)
-			} else if os.IsNotExist(err) {
-				fmt.Printf("Fichier %s déjà supprimé.\n", pluginFile)
-			} else {
-				fmt.Printf("Erreur suppression du fichier %s: %s\n", pluginFile, err)
-			}
-
-			// --- Supprimer le bloc du ~/.bashrc ---
-			content, err := os.ReadFile(bashrcPath)
-			if err != nil {
-				fmt.Printf("Erreur lecture %s/.bashrc: %s\n", home, err)
-				return
-			}
-
-			newContent := strings.ReplaceAll(string(content), pluginBlock, "")
-			if err := os.WriteFile(bashrcPath, []byte(newContent), 0644); err != nil {
-				fmt.Printf("Erreur écriture %s/.bashrc: %s\n", home, err)
-				return
-			}
-			fmt.Printf("Bloc plugin supprimé de %s/.bashrc\n", home)
-
-			// --- Supprimer le repertoire ~/.Plugin/Plugin ---
-			if err := os.RemoveAll(pluginDir); err == nil {
-				fmt.Printf("Répertoire %s supprimé.\n", pluginDir)
-			} else {
-				fmt.Printf("Erreur suppression du répertoire %s: %s\n", pluginDir, err)
-			}
-
-			// --- Supprimer le fichier ~/.Plugin/Chargeur ---
-			if err := os.Remove(chargeurFile); err == nil {
-				fmt.Printf("Fichier %s supprimé.\n", chargeurFile)
-			} else if os.IsNotExist(err) {
-				fmt.Printf("Fichier %s déjà supprimé.\n", chargeurFile)
-			} else {
-				fmt.Printf("Erreur suppression du fichier %s: %s\n", chargeurFile, err)
-			}
-			return
-
-		default:
-			fmt.Printf("Commande inconnue: %s\n", os.Args[1])
-			return
+			fmt.Println("Erreur mode développement:", err)
+			return exitError
 		}
+		m.dev = w
 	}
 
 	p := tea.NewProgram(
 		m,
 		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
 	)
 
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Erreur: %v\n", err)
-		os.Exit(1)
+		return exitError
 	}
+	return exitOK
 }
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Manifeste optionnel d'un plugin (<plugin>.json publié à côté du fichier dans le dépôt)
+type pluginManifest struct {
+	Name         string       `json:"name"`
+	Version      string       `json:"version"`
+	Description  string       `json:"description"`
+	Capabilities capabilities `json:"capabilities"`
+	Changelog    []string     `json:"changelog,omitempty"` // Modifications, la plus récente en premier
+	Keys         []pluginKey  `json:"keys,omitempty"`      // Raccourcis du plugin (aide de l'hôte)
+}
+
+// Raccourci déclaré par un plugin
+type pluginKey struct {
+	Key  string `json:"key"`
+	Help string `json:"help"`
+}
+
+// Capacités déclarées par un plugin
+type capabilities struct {
+	Network    bool     `json:"network,omitempty"`    // Accès réseau
+	Filesystem []string `json:"filesystem,omitempty"` // Chemins accédés
+	Exec       bool     `json:"exec,omitempty"`       // Exécution de commandes
+	Sudo       bool     `json:"sudo,omitempty"`       // Élévation de privilèges
+}
+
+// Vérifier si aucune capacité n'est déclarée
+func (c capabilities) empty() bool {
+	return !c.Network && len(c.Filesystem) == 0 && !c.Exec && !c.Sudo
+}
+
+// Comparer deux déclarations de capacités (chemins comparés comme ensembles : l'ordre ne compte pas)
+func (c capabilities) equal(other capabilities) bool {
+	if c.Network != other.Network || c.Exec != other.Exec || c.Sudo != other.Sudo {
+		return false
+	}
+	return maps.Equal(pathSet(c.Filesystem), pathSet(other.Filesystem))
+}
+
+func pathSet(paths []string) map[string]bool {
+	set := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		set[path] = true
+	}
+	return set
+}
+
+// Description lisible des capacités, une par ligne
+func (c capabilities) describe() []string {
+	var lines []string
+	if c.Network {
+		lines = append(lines, "🌐 Accès réseau")
+	}
+	if len(c.Filesystem) > 0 {
+		lines = append(lines, "📁 Fichiers : "+strings.Join(c.Filesystem, ", "))
+	}
+	if c.Exec {
+		lines = append(lines, "⚙️ Exécution de commandes")
+	}
+	if c.Sudo {
+		lines = append(lines, "🔑 Privilèges administrateur (sudo)")
+	}
+	return lines
+}
+
+// Nom du manifeste associé à un fichier plugin
+func manifestName(filename string) string {
+	return pluginName(filename) + ".json"
+}
+
+// Séparer les plugins de leurs manifestes dans le contenu d'un dépôt
+func splitManifests(files []GitHubFile) ([]GitHubFile, map[string]GitHubFile) {
+	names := make(map[string]bool)
+	for _, file := range files {
+		if filepath.Ext(file.Name) != ".json" {
+			names[pluginName(file.Name)] = true
+		}
+	}
+
+	var plugins []GitHubFile
+	manifests := make(map[string]GitHubFile)
+	for _, file := range files {
+		if filepath.Ext(file.Name) == ".json" && names[pluginName(file.Name)] {
+			manifests[pluginName(file.Name)] = file
+			continue
+		}
+		plugins = append(plugins, file)
+	}
+	return plugins, manifests
+}
+
+// Manifeste d'un fichier du dépôt (zéro si non publié)
+func (r Repository) manifestFor(file GitHubFile) (GitHubFile, bool) {
+	manifest, ok := r.Manifests[pluginName(file.Name)]
+	return manifest, ok
+}
+
+// Lire le manifeste installé d'un plugin (nil si absent)
+func readLocalManifest(filename string, pluginDir string) (*pluginManifest, error) {
+	data, err := os.ReadFile(filepath.Join(pluginDir, manifestName(filename)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest pluginManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("manifeste %s invalide: %v", manifestName(filename), err)
+	}
+	return &manifest, nil
+}
+
+// Télécharger le manifeste publié d'un plugin
+func fetchManifest(file GitHubFile) (*pluginManifest, error) {
+	resp, err := http.Get(file.DownloadURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP %d pour %s", resp.StatusCode, file.DownloadURL)
+	}
+
+	var manifest pluginManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("manifeste %s invalide: %v", file.Name, err)
+	}
+	return &manifest, nil
+}
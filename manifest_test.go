@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestCapabilitiesEqual(t *testing.T) {
+	tests := []struct {
+		a, b capabilities
+		want bool
+	}{
+		{a: capabilities{}, b: capabilities{}, want: true},
+		{a: capabilities{Filesystem: []string{"~/.ssh", "/etc/hosts"}}, b: capabilities{Filesystem: []string{"/etc/hosts", "~/.ssh"}}, want: true},
+		{a: capabilities{Filesystem: []string{"~/.ssh", "~/.ssh"}}, b: capabilities{Filesystem: []string{"~/.ssh"}}, want: true},
+		{a: capabilities{Filesystem: []string{"~/.ssh"}}, b: capabilities{Filesystem: []string{"~/.ssh", "/etc/hosts"}}, want: false},
+		{a: capabilities{Filesystem: []string{"~/.ssh"}}, b: capabilities{Filesystem: []string{"/etc/hosts"}}, want: false},
+		{a: capabilities{Network: true}, b: capabilities{}, want: false},
+		{a: capabilities{Sudo: true, Exec: true}, b: capabilities{Exec: true, Sudo: true}, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.equal(tt.b); got != tt.want {
+			t.Errorf("%+v.equal(%+v) = %v, attendu %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := tt.b.equal(tt.a); got != tt.want {
+			t.Errorf("%+v.equal(%+v) = %v, attendu %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
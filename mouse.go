@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Marque d'un plugin sélectionné, dans la case cliquable en tête de ligne
+const selectionMark = "✓"
+
+// Largeur de la case de sélection en tête des lignes du panel 1
+const selectionColumns = 2
+
+// Lignes parcourues par un cran de molette
+const wheelStep = 1
+
+// Zone de l'écran sous la souris
+const (
+	areaNone = iota
+	areaTabs
+	areaPresent
+	areaInstall
+	areaLog
+	areaRight
+)
+
+// Zone sous un point de l'écran (bordure comprise) et position relative au contenu de son panel
+func (m model) mouseArea(x, y int) (int, int, int) {
+	l := m.layout()
+	if l.narrow && y == 0 {
+		return areaTabs, x, 0
+	}
+	for _, panel := range []struct {
+		area int
+		r    rect
+	}{{areaPresent, l.present}, {areaInstall, l.install}, {areaLog, l.log}, {areaRight, l.right}} {
+		if panel.r.visible() && x >= panel.r.x-1 && x <= panel.r.x+panel.r.w && y >= panel.r.y-1 && y <= panel.r.y+panel.r.h {
+			return panel.area, x - panel.r.x, y - panel.r.y
+		}
+	}
+	return areaNone, 0, 0
+}
+
+// Ligne du panel 1 affichée à une hauteur du contenu (-1 si aucune)
+func (m model) installLineAt(row int) int {
+	if m.loading || m.err != nil || len(m.repos) == 0 {
+		return -1
+	}
+	height := m.layout().install.h
+	// Ligne de recherche en tête du panel
+	if m.searching || m.searchQuery != "" {
+		row--
+		height--
+	}
+	start, end := m.installWindow(height)
+	if row < 0 || start+row >= end {
+		return -1
+	}
+	return start + row
+}
+
+// Souris : clic pour activer un panel, choisir un plugin ou cocher sa case, molette pour faire défiler.
+// Dans le panel du plugin, les événements lui sont transmis en coordonnées locales.
+func (m model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.fullScreen && m.embeddedTUI != nil {
+		var cmd tea.Cmd
+		m.embeddedTUI, cmd = m.embeddedTUI.Update(msg)
+		return m, cmd
+	}
+	if m.dialog != nil || m.help != nil || m.searching {
+		return m, nil
+	}
+
+	area, x, y := m.mouseArea(msg.X, msg.Y)
+	l := m.layout()
+	if area == areaRight && m.embeddedTUI != nil && (!l.narrow || m.activePanel == 3) {
+		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			m.activePanel = 3
+		}
+		// Bordure : activer le panel sans rien transmettre
+		if !l.right.contains(msg.X, msg.Y) {
+			return m, nil
+		}
+		local := msg
+		local.X, local.Y = x, y
+		var cmd tea.Cmd
+		m.embeddedTUI, cmd = m.embeddedTUI.Update(local)
+		return m, cmd
+	}
+
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp || msg.Button == tea.MouseButtonWheelDown:
+		return m.handleWheel(area, msg.Button == tea.MouseButtonWheelUp)
+	case msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft:
+		return m, nil
+	case m.loading || m.processing:
+		return m, nil
+	}
+
+	switch area {
+	case areaTabs:
+		if panel := m.tabAt(x); panel >= 0 {
+			m.activePanel = panel
+			m.scrollOffset = 0
+		}
+	case areaPresent:
+		m.activePanel = 0
+	case areaLog:
+		m.activePanel = 2
+		m.scrollOffset = 0
+	case areaInstall:
+		m.activePanel = 1
+		i := m.installLineAt(y)
+		if i < 0 {
+			break
+		}
+		m.cursor = i
+		line := m.displayLines[i]
+		if x < 0 || x >= selectionColumns {
+			break
+		}
+		// Case de sélection d'un plugin, indicateur de pliage d'un dépôt
+		if line.isHeader {
+			m.repos[line.repoIdx].Collapsed = !m.repos[line.repoIdx].Collapsed
+			m.refreshDisplayLines()
+			break
+		}
+		key := fmt.Sprintf("%d:%d", line.repoIdx, line.fileIdx)
+		if m.selected[key] {
+			delete(m.selected, key)
+		} else {
+			m.selected[key] = true
+		}
+		if m.view == viewSelected {
+			m.refreshDisplayLines()
+		}
+	}
+
+	if m.activePanel == 1 && m.embeddedTUI == nil {
+		return m, m.scheduleDetails()
+	}
+	return m, nil
+}
+
+// Molette : liste du panel 1 ou logs (affichés à droite quand le panel des logs est actif)
+func (m model) handleWheel(area int, up bool) (tea.Model, tea.Cmd) {
+	switch {
+	case area == areaInstall && !m.processing && len(m.displayLines) > 0:
+		m.activePanel = 1
+		if up {
+			m.cursor = max(0, m.cursor-wheelStep)
+		} else {
+			m.cursor = min(len(m.displayLines)-1, m.cursor+wheelStep)
+		}
+		if m.embeddedTUI == nil {
+			return m, m.scheduleDetails()
+		}
+	case area == areaLog || (area == areaRight && m.activePanel == 2):
+		m.activePanel = 2
+		// Même sens que ↑/↓ : les logs récents sont en haut
+		if up {
+			m.scrollOffset = max(0, m.scrollOffset-wheelStep)
+		} else {
+			m.scrollOffset = min(m.maxLogScroll(), m.scrollOffset+wheelStep)
+		}
+	}
+	return m, nil
+}
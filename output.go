@@ -0,0 +1,259 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// Version du schéma JSON : à incrémenter à chaque changement incompatible
+const outputSchemaVersion = 1
+
+// Formats de sortie des commandes
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// Sortie JSON commune à toutes les commandes
+type jsonOutput struct {
+	SchemaVersion int          `json:"schema_version"`
+	Command       string       `json:"command"`
+	Repos         []jsonRepo   `json:"repos,omitempty"`
+	Plugin        *jsonPlugin  `json:"plugin,omitempty"`
+	Plugins       []jsonPlugin `json:"plugins,omitempty"`
+	Checks        []jsonCheck  `json:"checks,omitempty"`
+	Errors        []jsonError  `json:"errors"`
+}
+
+type jsonRepo struct {
+	Name    string       `json:"name"`
+	URL     string       `json:"url"`
+	Trust   string       `json:"trust"` // "signed", "unsigned", "blocked" ou "invalid_key"
+	Plugins []jsonPlugin `json:"plugins"`
+}
+
+type jsonPlugin struct {
+	Name        string           `json:"name"`
+	Repo        string           `json:"repo"`
+	DownloadURL string           `json:"download_url,omitempty"`
+	SHA         string           `json:"sha,omitempty"`
+	Size        int64            `json:"size"`
+	State       string           `json:"state"` // "available", "installed", "updatable", "foreign", "orphaned", "missing" ou "unreachable"
+	Alias       string           `json:"alias"` // Alias généré ("" : plugin non installé ou alias refusé)
+	Installed   *jsonInstalled   `json:"installed,omitempty"`
+	Manifest    *pluginManifest  `json:"manifest,omitempty"`
+	Permission  *permissionGrant `json:"permission,omitempty"`
+}
+
+type jsonInstalled struct {
+	SHA         string    `json:"sha"`
+	Size        int64     `json:"size"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+type jsonCheck struct {
+	Name    string `json:"name"`   // "layout", "chargeur", "alias_file", "rc_block", "aliases", "alias_names", "keys", "theme", "repos", "rate_limit" ou "abi"
+	Status  string `json:"status"` // "ok", "warn" ou "fail"
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"`
+	Fixable bool   `json:"fixable"` // Corrigeable par --fix
+	Fixed   bool   `json:"fixed"`
+}
+
+type jsonError struct {
+	Code    string `json:"code"` // "error", "usage", "not_found" ou "repo"
+	Message string `json:"message"`
+}
+
+// Déclarer --output/-o sur les options d'une commande
+func outputFlag(fs *flag.FlagSet) *string {
+	format := outputText
+	set := func(value string) error {
+		if value != outputText && value != outputJSON {
+			return fmt.Errorf("format de sortie inconnu: %s (text ou json)", value)
+		}
+		format = value
+		return nil
+	}
+	fs.Func("output", "Format de sortie `text|json` (défaut text)", set)
+	fs.Func("o", "Raccourci de --output", set)
+	return &format
+}
+
+// Plugin d'un dépôt au format JSON
+func newJSONPlugin(repo Repository, file GitHubFile, local bool, state installedState, aliases map[string]string) jsonPlugin {
+	p := jsonPlugin{
+		Name:        file.Name,
+		Repo:        repo.Name,
+		DownloadURL: file.DownloadURL,
+		SHA:         file.SHA,
+		Size:        file.Size,
+		State:       pluginState(repo, file, local, state),
+	}
+	if local {
+		p.Alias = aliases[file.Name]
+	}
+	if installed, ok := state.Plugins[file.Name]; ok && local {
+		p.Installed = &jsonInstalled{SHA: installed.SHA, Size: installed.Size, InstalledAt: installed.InstalledAt}
+	}
+	return p
+}
+
+// Erreurs de chargement des dépôts au format JSON
+func repoErrorsJSON(repoErrors []error) []jsonError {
+	errs := []jsonError{}
+	for _, err := range repoErrors {
+		errs = append(errs, jsonError{Code: "repo", Message: err.Error()})
+	}
+	return errs
+}
+
+// Sortie de "list" : dépôts et leurs plugins
+func listOutput(repos []Repository, localFiles map[string]bool, state installedState, aliases map[string]string, repoErrors []error) jsonOutput {
+	out := jsonOutput{SchemaVersion: outputSchemaVersion, Command: "list", Repos: []jsonRepo{}, Errors: repoErrorsJSON(repoErrors)}
+	for _, repo := range repos {
+		r := jsonRepo{Name: repo.Name, URL: repo.URL, Trust: repoTrust(repo), Plugins: []jsonPlugin{}}
+		for _, file := range repo.Files {
+			r.Plugins = append(r.Plugins, newJSONPlugin(repo, file, localFiles[localKey(repo.Name, file.Name)], state, aliases))
+		}
+		out.Repos = append(out.Repos, r)
+	}
+	return out
+}
+
+// Sortie de "info" : un plugin avec son manifeste et ses permissions
+func infoOutput(p repoPlugin, local bool, state installedState, aliases map[string]string, manifest *pluginManifest, grant *permissionGrant) jsonOutput {
+	plugin := newJSONPlugin(p.repo, p.file, local, state, aliases)
+	plugin.Manifest = manifest
+	plugin.Permission = grant
+	return jsonOutput{SchemaVersion: outputSchemaVersion, Command: "info", Plugin: &plugin, Errors: []jsonError{}}
+}
+
+// Sortie de "status" : plugins installés, triés par nom
+func statusOutput(repos []Repository, localFiles map[string]bool, state installedState, aliases map[string]string, repoErrors []error) jsonOutput {
+	out := jsonOutput{SchemaVersion: outputSchemaVersion, Command: "status", Plugins: []jsonPlugin{}, Errors: repoErrorsJSON(repoErrors)}
+
+	// Plugins publiés par les dépôts chargés, clé: localKey
+	published := make(map[string]repoPlugin)
+	publishers := make(map[string][]string)
+	for _, repo := range repos {
+		for _, file := range repo.Files {
+			published[localKey(repo.Name, file.Name)] = repoPlugin{repo: repo, file: file}
+			publishers[file.Name] = append(publishers[file.Name], repo.Name)
+		}
+	}
+	unreachable := make(map[string]bool)
+	for _, err := range repoErrors {
+		var repoErr repoError
+		if errors.As(err, &repoErr) {
+			unreachable[repoErr.repo] = true
+		}
+	}
+
+	// Plugins présents sans installation enregistrée : premier dépôt qui les publie
+	seen := make(map[string]bool)
+	for _, repo := range repos {
+		for _, file := range repo.Files {
+			if _, recorded := state.Plugins[file.Name]; recorded || seen[file.Name] || !localFiles[localKey(repo.Name, file.Name)] {
+				continue
+			}
+			seen[file.Name] = true
+			out.Plugins = append(out.Plugins, newJSONPlugin(repo, file, true, state, aliases))
+		}
+	}
+
+	// Plugins installés, rapprochés du dépôt de leur installation
+	for name, installed := range state.Plugins {
+		repoName := installed.Repo
+		if repoName == "" && len(publishers[name]) > 0 {
+			repoName = publishers[name][0]
+		}
+		if p, ok := published[localKey(repoName, name)]; ok {
+			plugin := newJSONPlugin(p.repo, p.file, localFiles[localKey(repoName, name)], state, aliases)
+			if plugin.Installed == nil {
+				plugin.State = stateMissing
+				plugin.Installed = &jsonInstalled{SHA: installed.SHA, Size: installed.Size, InstalledAt: installed.InstalledAt}
+			}
+			out.Plugins = append(out.Plugins, plugin)
+			continue
+		}
+
+		status := stateOrphaned
+		switch {
+		case unreachable[repoName]:
+			status = stateUnreachable
+		case len(publishers[name]) > 0:
+			// Plus publié par son dépôt, mais par un autre
+			status = stateForeign
+		}
+		out.Plugins = append(out.Plugins, jsonPlugin{
+			Name:      name,
+			Repo:      installed.Repo,
+			Size:      installed.Size,
+			State:     status,
+			Alias:     aliases[name],
+			Installed: &jsonInstalled{SHA: installed.SHA, Size: installed.Size, InstalledAt: installed.InstalledAt},
+		})
+	}
+
+	sort.Slice(out.Plugins, func(i, j int) bool {
+		return out.Plugins[i].Name < out.Plugins[j].Name
+	})
+	return out
+}
+
+// Sortie d'une commande en échec
+func errorOutput(command string, err error, code int) jsonOutput {
+	name := "error"
+	switch code {
+	case exitUsage:
+		name = "usage"
+	case exitNotFound:
+		name = "not_found"
+	}
+	return jsonOutput{SchemaVersion: outputSchemaVersion, Command: command, Errors: []jsonError{{Code: name, Message: err.Error()}}}
+}
+
+// Sortie de Pannel doctor
+func doctorOutput(checks []doctorCheck) jsonOutput {
+	out := jsonOutput{SchemaVersion: outputSchemaVersion, Command: "doctor", Checks: []jsonCheck{}, Errors: []jsonError{}}
+	for _, c := range checks {
+		out.Checks = append(out.Checks, jsonCheck{
+			Name:    c.name,
+			Status:  c.status,
+			Message: c.message,
+			Fix:     c.fix,
+			Fixable: c.apply != nil,
+			Fixed:   c.fixed,
+		})
+	}
+	return out
+}
+
+// Écrire une sortie JSON indentée
+func writeJSON(w io.Writer, out jsonOutput) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// Terminer une commande en erreur dans le format demandé
+func exitWithErrorFormat(command string, format string, err error) int {
+	if format != outputJSON {
+		return exitWithError(err)
+	}
+	code := exitError
+	if errors.Is(err, errPluginNotFound) {
+		code = exitNotFound
+	}
+	if werr := writeJSON(os.Stdout, errorOutput(command, err, code)); werr != nil {
+		fmt.Fprintf(os.Stderr, "Erreur: %v\n", werr)
+	}
+	return code
+}
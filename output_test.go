@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "réécrire les fichiers golden de testdata/")
+
+// Dépôts et installation de référence pour les sorties JSON
+func fixtureRepos() ([]Repository, map[string]bool, installedState) {
+	repos := []Repository{
+		{
+			Name:      "TWilhem/Plugin",
+			URL:       "https://api.github.com/repos/TWilhem/Plugin/contents/Plugin",
+			PublicKey: "q0cBqP9m4C5QSkPjM9k2o8v3m3t2YQ1Jv1XcJ0q3x9E=",
+			Files: []GitHubFile{
+				{Name: "Journal.so", Type: "file", DownloadURL: "https://raw.githubusercontent.com/TWilhem/Plugin/main/Plugin/Journal.so", SHA: "1111111111111111111111111111111111111111", Size: 4096},
+				{Name: "Reseau.so", Type: "file", DownloadURL: "https://raw.githubusercontent.com/TWilhem/Plugin/main/Plugin/Reseau.so", SHA: "2222222222222222222222222222222222222222", Size: 8192},
+				{Name: "Disque.so", Type: "file", DownloadURL: "https://raw.githubusercontent.com/TWilhem/Plugin/main/Plugin/Disque.so", SHA: "3333333333333333333333333333333333333333", Size: 2048},
+			},
+		},
+		{
+			Name:    "AutreUser/AutreRepo",
+			URL:     "https://api.github.com/repos/AutreUser/AutreRepo/contents/Plugins",
+			Blocked: true,
+			Files: []GitHubFile{
+				{Name: "Outil.so", Type: "file", DownloadURL: "https://raw.githubusercontent.com/AutreUser/AutreRepo/main/Plugins/Outil.so", SHA: "4444444444444444444444444444444444444444", Size: 1024},
+			},
+		},
+	}
+
+	localFiles := map[string]bool{"TWilhem/Plugin/Journal.so": true, "TWilhem/Plugin/Reseau.so": true}
+
+	installedAt := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+	state := installedState{Plugins: map[string]installedPlugin{
+		"Journal.so": {Repo: "TWilhem/Plugin", SHA: "1111111111111111111111111111111111111111", Size: 4096, InstalledAt: installedAt},
+		"Reseau.so":  {Repo: "TWilhem/Plugin", SHA: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Size: 8000, InstalledAt: installedAt},
+		"Ancien.so":  {Repo: "TWilhem/Plugin", SHA: "5555555555555555555555555555555555555555", Size: 512, InstalledAt: installedAt},
+	}}
+
+	return repos, localFiles, state
+}
+
+// Alias des plugins installés : Reseau.so renommé, Ancien.so refusé (nom réservé)
+func fixtureAliases(t *testing.T) map[string]string {
+	t.Helper()
+	t.Setenv("PATH", t.TempDir())
+	baseDir := t.TempDir()
+	overrides := aliasOverrides{Aliases: map[string]string{"Reseau.so": "reseau-diag", "Ancien.so": "Plugin"}}
+	if err := saveAliasOverrides(overrides, baseDir); err != nil {
+		t.Fatal(err)
+	}
+	files := []string{"Journal.so", "Reseau.so", "Ancien.so", "Outil.so", "Interne.so", "Perdu.so"}
+	names, _, err := resolveAliasNames(files, baseDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return names
+}
+
+// Comparer une sortie JSON au fichier golden correspondant
+func assertGolden(t *testing.T, name string, out jsonOutput) {
+	t.Helper()
+
+	var got bytes.Buffer
+	if err := writeJSON(&got, out); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, got.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Errorf("sortie différente de %s:\n%s", path, got.String())
+	}
+}
+
+func TestListOutput(t *testing.T) {
+	repos, localFiles, state := fixtureRepos()
+	repoErrors := []error{errors.New("Plugins internes: HTTP 404 pour https://api.github.com/repos/MonOrganisation/Plugins/contents/")}
+	assertGolden(t, "list", listOutput(repos, localFiles, state, fixtureAliases(t), repoErrors))
+}
+
+func TestInfoOutput(t *testing.T) {
+	repos, localFiles, state := fixtureRepos()
+	p := repoPlugin{repo: repos[0], file: repos[0].Files[1]}
+	manifest := &pluginManifest{
+		Name:        "Reseau",
+		Version:     "1.2.0",
+		Description: "Diagnostic réseau",
+		Capabilities: capabilities{
+			Network:    true,
+			Filesystem: []string{"/etc/hosts"},
+			Exec:       true,
+		},
+	}
+	grant := &permissionGrant{
+		Version:      "1.1.0",
+		Capabilities: capabilities{Network: true},
+		GrantedAt:    time.Date(2025, 3, 15, 10, 0, 0, 0, time.UTC),
+		GrantedBy:    "tom",
+	}
+	assertGolden(t, "info", infoOutput(p, localFiles[localKey(p.repo.Name, p.file.Name)], state, fixtureAliases(t), manifest, grant))
+}
+
+func TestStatusOutput(t *testing.T) {
+	repos, localFiles, state := fixtureRepos()
+	assertGolden(t, "status", statusOutput(repos, localFiles, state, fixtureAliases(t), nil))
+}
+
+// États de status autres qu'orphelin : fichier supprimé, dépôt injoignable, dépôt d'installation
+func TestStatusOutputStates(t *testing.T) {
+	repos, localFiles, state := fixtureRepos()
+	installedAt := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+
+	// Outil.so publié par deux dépôts, installé depuis le second
+	repos = append(repos, Repository{
+		Name: "Tiers/Outils",
+		URL:  "https://api.github.com/repos/Tiers/Outils/contents/",
+		Files: []GitHubFile{
+			{Name: "Outil.so", Type: "file", DownloadURL: "https://raw.githubusercontent.com/Tiers/Outils/main/Outil.so", SHA: "6666666666666666666666666666666666666666", Size: 1536},
+			{Name: "Ancien.so", Type: "file", DownloadURL: "https://raw.githubusercontent.com/Tiers/Outils/main/Ancien.so", SHA: "7777777777777777777777777777777777777777", Size: 640},
+		},
+	})
+	localFiles[localKey("Tiers/Outils", "Outil.so")] = true
+	state.Plugins["Outil.so"] = installedPlugin{Repo: "Tiers/Outils", SHA: "6666666666666666666666666666666666666666", Size: 1536, InstalledAt: installedAt}
+	// Fichier local de Disque.so supprimé
+	state.Plugins["Disque.so"] = installedPlugin{Repo: "TWilhem/Plugin", SHA: "3333333333333333333333333333333333333333", Size: 2048, InstalledAt: installedAt}
+	// Dépôt injoignable
+	state.Plugins["Interne.so"] = installedPlugin{Repo: "Plugins internes", SHA: "8888888888888888888888888888888888888888", Size: 256, InstalledAt: installedAt}
+	// Vraiment orphelin : aucun dépôt chargé ne le publie
+	state.Plugins["Perdu.so"] = installedPlugin{Repo: "TWilhem/Plugin", SHA: "9999999999999999999999999999999999999999", Size: 128, InstalledAt: installedAt}
+
+	repoErrors := []error{repoError{repo: "Plugins internes", err: errors.New("HTTP 404 pour https://api.github.com/repos/MonOrganisation/Plugins/contents/")}}
+	assertGolden(t, "status_states", statusOutput(repos, localFiles, state, fixtureAliases(t), repoErrors))
+}
+
+func TestDoctorOutput(t *testing.T) {
+	checks := []doctorCheck{
+		{name: "layout", title: "Dossiers", status: checkOK, message: "/home/tom/.Plugin/Plugin présent"},
+		{name: "chargeur", title: "Chargeur", status: checkFail, message: "/home/tom/.Plugin/Chargeur n'est pas exécutable",
+			fix: "chmod +x /home/tom/.Plugin/Chargeur", apply: func() error { return nil }, fixed: true},
+		{name: "aliases", title: "Alias", status: checkWarn, message: "alias manquant pour Journal.so",
+			fix: "Ajouter l'alias Journal", apply: func() error { return nil }},
+		{name: "abi", title: "ABI", status: checkFail, message: "Reseau.so compilé avec go1.23.4 (Pannel: go1.24.8)",
+			fix: "Pannel update Reseau, ou recompiler avec go1.24.8 et les dépendances de « Pannel version »"},
+	}
+	assertGolden(t, "doctor", doctorOutput(checks))
+}
+
+func TestErrorOutput(t *testing.T) {
+	repos, _, _ := fixtureRepos()
+	_, err := findPlugin(repos, "Inconnu")
+	assertGolden(t, "error", errorOutput("info", err, exitNotFound))
+}
+
+func TestOutputFlag(t *testing.T) {
+	tests := []struct {
+		args    []string
+		maxArgs int
+		format  string
+		rest    []string
+		err     bool
+	}{
+		{args: nil, maxArgs: -1, format: outputText},
+		{args: []string{"Journal", "--output", "json"}, maxArgs: -1, format: outputJSON, rest: []string{"Journal"}},
+		{args: []string{"--output=json", "Journal"}, maxArgs: -1, format: outputJSON, rest: []string{"Journal"}},
+		{args: []string{"-o", "text", "--verbose"}, maxArgs: -1, format: outputText},
+		{args: []string{"--", "-o"}, maxArgs: -1, format: outputText, rest: []string{"-o"}},
+		{args: []string{"Journal", "-o", "json"}, maxArgs: 1, format: outputText, rest: []string{"Journal", "-o", "json"}},
+		{args: []string{"--output"}, maxArgs: -1, err: true},
+		{args: []string{"--output", "yaml"}, maxArgs: -1, err: true},
+	}
+
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		format := outputFlag(fs)
+		(&globalOptions{}).register(fs)
+
+		rest, err := parseArgs(fs, tt.args, tt.maxArgs)
+		if (err != nil) != tt.err {
+			t.Errorf("parseArgs(%q) erreur = %v", tt.args, err)
+			continue
+		}
+		if tt.err {
+			continue
+		}
+		if *format != tt.format || strings.Join(rest, " ") != strings.Join(tt.rest, " ") {
+			t.Errorf("parseArgs(%q) = %q, %q", tt.args, *format, rest)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Permissions accordées aux plugins (baseDir/permissions.json), consultables par un administrateur
+type permissionsFile struct {
+	Plugins map[string]permissionGrant `json:"plugins"`
+}
+
+// Consentement donné pour un plugin
+type permissionGrant struct {
+	Version      string       `json:"version,omitempty"`
+	Capabilities capabilities `json:"capabilities"`
+	GrantedAt    time.Time    `json:"granted_at"`
+	GrantedBy    string       `json:"granted_by"`
+}
+
+func permissionsPath(baseDir string) string {
+	return filepath.Join(baseDir, "permissions.json")
+}
+
+// Lire les permissions enregistrées
+func loadPermissions(baseDir string) (permissionsFile, error) {
+	perms := permissionsFile{Plugins: make(map[string]permissionGrant)}
+
+	data, err := os.ReadFile(permissionsPath(baseDir))
+	if os.IsNotExist(err) {
+		return perms, nil
+	}
+	if err != nil {
+		return perms, err
+	}
+	if err := json.Unmarshal(data, &perms); err != nil {
+		return perms, fmt.Errorf("erreur parsing permissions.json: %v", err)
+	}
+	if perms.Plugins == nil {
+		perms.Plugins = make(map[string]permissionGrant)
+	}
+	return perms, nil
+}
+
+func savePermissions(perms permissionsFile, baseDir string) error {
+	data, err := json.MarshalIndent(perms, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(permissionsPath(baseDir), data, 0644)
+}
+
+// Enregistrer le consentement pour un plugin
+func grantPermission(filename string, manifest *pluginManifest, baseDir string) error {
+	perms, err := loadPermissions(baseDir)
+	if err != nil {
+		return err
+	}
+
+	grant := permissionGrant{GrantedAt: time.Now().UTC()}
+	if manifest != nil {
+		grant.Version = manifest.Version
+		grant.Capabilities = manifest.Capabilities
+	}
+	if usr, err := user.Current(); err == nil {
+		grant.GrantedBy = usr.Username
+	}
+
+	perms.Plugins[filename] = grant
+	return savePermissions(perms, baseDir)
+}
+
+// Oublier le consentement d'un plugin supprimé
+func revokePermission(filename string, baseDir string) error {
+	perms, err := loadPermissions(baseDir)
+	if err != nil {
+		return err
+	}
+	if _, ok := perms.Plugins[filename]; !ok {
+		return nil
+	}
+	delete(perms.Plugins, filename)
+	return savePermissions(perms, baseDir)
+}
+
+// Demande de consentement pour exécuter un plugin
+type consentRequest struct {
+	manifest *pluginManifest // Manifeste installé (nil si absent)
+	needed   bool            // Capacités nouvelles ou modifiées depuis le dernier consentement
+	lines    []string        // Description à présenter à l'utilisateur
+}
+
+// Vérifier si un plugin installé peut s'exécuter sans nouveau consentement
+func checkConsent(filename string, pluginDir string) (consentRequest, []error) {
+	var warnings []error
+
+	manifest, err := readLocalManifest(filename, pluginDir)
+	if err != nil {
+		warnings = append(warnings, err)
+	}
+	var requested capabilities
+	if manifest != nil {
+		requested = manifest.Capabilities
+	}
+
+	perms, err := loadPermissions(filepath.Dir(pluginDir))
+	if err != nil {
+		warnings = append(warnings, err)
+	}
+	grant, granted := perms.Plugins[filename]
+	if granted && grant.Capabilities.equal(requested) {
+		return consentRequest{manifest: manifest}, warnings
+	}
+
+	var lines []string
+	if granted {
+		lines = append(lines, fmt.Sprintf("Les capacités de %s ont changé", filename))
+		if manifest != nil && grant.Version != "" && grant.Version != manifest.Version {
+			lines = append(lines, fmt.Sprintf("(version %s → %s)", grant.Version, manifest.Version))
+		}
+	} else {
+		lines = append(lines, fmt.Sprintf("Première exécution de %s", filename))
+	}
+	lines = append(lines, "")
+
+	if manifest == nil {
+		lines = append(lines, "⚠️ Aucun manifeste : capacités inconnues")
+	} else if requested.empty() {
+		lines = append(lines, "Aucune capacité déclarée")
+	} else {
+		lines = append(lines, "Le plugin demande :")
+		for _, line := range requested.describe() {
+			lines = append(lines, "  "+line)
+		}
+	}
+	lines = append(lines, "", "Le plugin s'exécute avec vos droits utilisateur.")
+
+	return consentRequest{manifest: manifest, needed: true, lines: lines}, warnings
+}
+
+// Lancer un plugin installé, après consentement si ses capacités sont nouvelles ou ont changé
+func (m model) runPlugin(filename string) (tea.Model, tea.Cmd) {
+	baseDir := filepath.Dir(m.pluginDir)
+
+	consent, warnings := checkConsent(filename, m.pluginDir)
+	for _, err := range warnings {
+		m.addLog(fmt.Sprintf("⚠️ %v", err))
+	}
+	if !consent.needed {
+		return m.startPlugin(filename)
+	}
+
+	m.dialog = &dialog{
+		title: "Autoriser " + filename + " ?",
+		lines: consent.lines,
+		options: []dialogOption{
+			{keys: []string{"y"}, label: "Autoriser", action: func(m model) (tea.Model, tea.Cmd) {
+				if err := grantPermission(filename, consent.manifest, baseDir); err != nil {
+					m.addLog(fmt.Sprintf("⚠️ Impossible d'enregistrer les permissions de %s: %v", filename, err))
+				} else {
+					m.addLog(fmt.Sprintf("🔐 Permissions accordées à %s", filename))
+				}
+				return m.startPlugin(filename)
+			}},
+			{keys: []string{"n"}, label: "Refuser", action: func(m model) (tea.Model, tea.Cmd) {
+				m.addLog(fmt.Sprintf("⛔ Exécution de %s refusée", filename))
+				return m, nil
+			}},
+		},
+	}
+	return m, nil
+}
+
+// Charger un plugin installé dans le panel de droite
+func (m model) startPlugin(filename string) (tea.Model, tea.Cmd) {
+	m.runningTUI = filename
+	m.addLog(fmt.Sprintf("▶️ Chargement de %s", filename))
+	m.activePanel = 3
+	return m, loadPlugin(filename, m.pluginDir)
+}
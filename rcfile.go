@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Bornes du bloc de chargement ajouté au fichier de démarrage du shell
+const (
+	rcBlockBegin = "# >>> Pannel : chargement des alias (bloc géré, ne pas modifier) >>>"
+	rcBlockEnd   = "# <<< Pannel : chargement des alias <<<"
+)
+
+// Modification d'un fichier de démarrage du shell
+type rcEdit struct {
+	path    string // Fichier écrit (cible du lien symbolique le cas échéant)
+	exists  bool
+	current string // Contenu actuel ("" si absent)
+	content string // Contenu après modification
+}
+
+// Le fichier doit être réécrit
+func (e rcEdit) changed() bool {
+	return e.current != e.content
+}
+
+// Bloc géré : le bloc de chargement du shell entre les bornes de Pannel
+func managedRcBlock(block string) string {
+	return rcBlockBegin + "\n" + block + "\n" + rcBlockEnd + "\n"
+}
+
+// Préparer l'ajout (install) ou le retrait du bloc de chargement dans un fichier rc.
+// Le bloc sans bornes des versions précédentes est remplacé ou retiré de la même façon.
+func planRcBlock(rcPath string, block string, install bool) (rcEdit, error) {
+	edit := rcEdit{path: rcPath}
+	if resolved, err := filepath.EvalSymlinks(rcPath); err == nil {
+		edit.path = resolved
+	}
+
+	data, err := os.ReadFile(edit.path)
+	switch {
+	case err == nil:
+		edit.exists = true
+	case !os.IsNotExist(err):
+		return edit, err
+	}
+	edit.current = string(data)
+
+	managed := managedRcBlock(block)
+	if install && strings.Contains(edit.current, managed) {
+		// Déjà en place : ne pas le déplacer
+		edit.content = edit.current
+		return edit, nil
+	}
+
+	replacement := ""
+	if install {
+		replacement = managed
+	}
+	content, found := replaceRcBlock(edit.current, block, replacement)
+	if install && !found {
+		switch {
+		case content == "" || strings.HasSuffix(content, "\n\n"):
+		case strings.HasSuffix(content, "\n"):
+			content += "\n"
+		default:
+			content += "\n\n"
+		}
+		content += managed
+	}
+	edit.content = content
+	return edit, nil
+}
+
+// Remplacer sur place le premier bloc géré ou ancien bloc sans bornes, et retirer les suivants.
+// Sans remplacement, la ligne vide qui précède un bloc retiré l'est aussi si elle ne sépare plus rien.
+func replaceRcBlock(content string, block string, replacement string) (string, bool) {
+	if content == "" {
+		return content, false
+	}
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	legacy := strings.Split(block, "\n")
+
+	var kept []string
+	found := false
+	for i := 0; i < len(lines); i++ {
+		end := -1
+		switch {
+		case lines[i] == rcBlockBegin:
+			for j := i + 1; j < len(lines); j++ {
+				if lines[j] == rcBlockEnd {
+					end = j
+					break
+				}
+			}
+		case i+len(legacy) <= len(lines) && equalLines(lines[i:i+len(legacy)], legacy):
+			end = i + len(legacy) - 1
+		}
+		if end < 0 {
+			kept = append(kept, lines[i])
+			continue
+		}
+
+		if !found && replacement != "" {
+			kept = append(kept, strings.Split(strings.TrimSuffix(replacement, "\n"), "\n")...)
+		} else if n := len(kept); n > 0 && kept[n-1] == "" && (end == len(lines)-1 || lines[end+1] == "") {
+			kept = kept[:n-1]
+		}
+		found = true
+		i = end
+	}
+
+	if len(kept) == 0 {
+		return "", found
+	}
+	result := strings.Join(kept, "\n")
+	if strings.HasSuffix(content, "\n") {
+		result += "\n"
+	}
+	return result, found
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Écrire la modification : sauvegarde horodatée, droits conservés, fichier créé s'il est absent.
+// Retourne le chemin de la sauvegarde ("" si le fichier n'existait pas).
+func (e rcEdit) apply() (string, error) {
+	if !e.changed() {
+		return "", nil
+	}
+
+	mode := os.FileMode(0644)
+	backup := ""
+	if e.exists {
+		info, err := os.Stat(e.path)
+		if err != nil {
+			return "", err
+		}
+		mode = info.Mode().Perm()
+		backup = backupPath(e.path, time.Now())
+		if err := os.WriteFile(backup, []byte(e.current), mode); err != nil {
+			return "", fmt.Errorf("sauvegarde de %s: %v", e.path, err)
+		}
+	} else if err := os.MkdirAll(filepath.Dir(e.path), 0755); err != nil {
+		return "", err
+	}
+
+	tmp := e.path + ".pannel.tmp"
+	if err := os.WriteFile(tmp, []byte(e.content), mode); err != nil {
+		return backup, err
+	}
+	if err := os.Chmod(tmp, mode); err != nil {
+		os.Remove(tmp)
+		return backup, err
+	}
+	return backup, os.Rename(tmp, e.path)
+}
+
+// Fichier de sauvegarde horodaté, sans écraser une sauvegarde de la même seconde
+func backupPath(path string, now time.Time) string {
+	backup := fmt.Sprintf("%s.pannel-%s.bak", path, now.Format("20060102-150405"))
+	for n := 1; ; n++ {
+		if _, err := os.Lstat(backup); os.IsNotExist(err) {
+			return backup
+		}
+		backup = fmt.Sprintf("%s.pannel-%s-%d.bak", path, now.Format("20060102-150405"), n)
+	}
+}
+
+// Différences ligne à ligne, avec contexte (--dry-run)
+func (e rcEdit) diff() string {
+	if !e.changed() {
+		return ""
+	}
+	var b strings.Builder
+	if e.exists {
+		fmt.Fprintf(&b, "--- %s\n", e.path)
+	} else {
+		b.WriteString("--- /dev/null\n")
+	}
+	fmt.Fprintf(&b, "+++ %s\n", e.path)
+	b.WriteString(lineDiff(e.current, e.content, 3))
+	return b.String()
+}
+
+// Diff unifié simplifié (plus longue sous-suite commune) : lignes "-", "+" et contexte " "
+func lineDiff(before, after string, context int) string {
+	split := func(s string) []string {
+		if s == "" {
+			return nil
+		}
+		return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	}
+	a, b := split(before), split(after)
+
+	// lcs[i][j] : longueur de la sous-suite commune de a[i:] et b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, " "+a[i])
+			i, j = i+1, j+1
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, "+"+b[j])
+			j++
+		default:
+			ops = append(ops, "-"+a[i])
+			i++
+		}
+	}
+
+	// Ne garder que les changements et leur contexte
+	var out strings.Builder
+	last := -1
+	for k, op := range ops {
+		if op[0] == ' ' {
+			continue
+		}
+		start := max(k-context, last+1)
+		if last >= 0 && start > last+1 {
+			out.WriteString("@@\n")
+		}
+		for c := start; c <= k; c++ {
+			out.WriteString(ops[c] + "\n")
+		}
+		last = k
+		for c := k + 1; c < len(ops) && c <= k+context && ops[c][0] == ' '; c++ {
+			out.WriteString(ops[c] + "\n")
+			last = c
+		}
+	}
+	return out.String()
+}
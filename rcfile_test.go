@@ -0,0 +1,227 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplaceRcBlock(t *testing.T) {
+	block := posixSourceBlock("/home/tom/.pannel_aliases")
+	managed := managedRcBlock(block)
+
+	tests := []struct {
+		name        string
+		content     string
+		replacement string
+		want        string
+		found       bool
+	}{
+		{
+			name:        "ancien bloc sans bornes migré",
+			content:     "export A=1\n\n" + block + "\nalias ll='ls -l'\n",
+			replacement: managed,
+			want:        "export A=1\n\n" + managed + "alias ll='ls -l'\n",
+			found:       true,
+		},
+		{
+			name:        "doublons fusionnés",
+			content:     "export A=1\n\n" + managed + "\nexport B=2\n\n" + block + "\n\n" + managed,
+			replacement: managed,
+			want:        "export A=1\n\n" + managed + "\nexport B=2\n",
+			found:       true,
+		},
+		{
+			name:    "retrait entre deux lignes vides",
+			content: "export A=1\n\n" + managed + "\nexport B=2\n",
+			want:    "export A=1\n\nexport B=2\n",
+			found:   true,
+		},
+		{
+			name:    "retrait en fin de fichier",
+			content: "export A=1\n\n" + managed,
+			want:    "export A=1\n",
+			found:   true,
+		},
+		{
+			name:    "retrait sans ligne vide après le bloc",
+			content: "export A=1\n\n" + managed + "export B=2\n",
+			want:    "export A=1\n\nexport B=2\n",
+			found:   true,
+		},
+		{
+			name:    "retrait du seul contenu",
+			content: managed,
+			want:    "",
+			found:   true,
+		},
+		{
+			name:        "sans retour à la ligne final",
+			content:     "export A=1\n\n" + block,
+			replacement: managed,
+			want:        "export A=1\n\n" + strings.TrimSuffix(managed, "\n"),
+			found:       true,
+		},
+		{
+			name:        "bloc non terminé conservé",
+			content:     "export A=1\n" + rcBlockBegin + "\nexport B=2\n",
+			replacement: managed,
+			want:        "export A=1\n" + rcBlockBegin + "\nexport B=2\n",
+		},
+		{
+			name:        "fichier vide",
+			replacement: managed,
+		},
+	}
+
+	for _, tt := range tests {
+		got, found := replaceRcBlock(tt.content, block, tt.replacement)
+		if got != tt.want || found != tt.found {
+			t.Errorf("%s : %q (trouvé %v), attendu %q (trouvé %v)", tt.name, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestPlanRcBlock(t *testing.T) {
+	block := posixSourceBlock("/home/tom/.pannel_aliases")
+	managed := managedRcBlock(block)
+
+	tests := []struct {
+		name    string
+		current *string // nil : fichier absent
+		install bool
+		want    string
+	}{
+		{name: "fichier absent", install: true, want: managed},
+		{name: "ajout après une ligne vide", current: ptr("export A=1\n\n"), install: true, want: "export A=1\n\n" + managed},
+		{name: "ajout après une ligne", current: ptr("export A=1\n"), install: true, want: "export A=1\n\n" + managed},
+		{name: "ajout sans retour à la ligne final", current: ptr("export A=1"), install: true, want: "export A=1\n\n" + managed},
+		{name: "déjà en place", current: ptr(managed + "\nexport A=1\n\n" + block + "\n"), install: true, want: managed + "\nexport A=1\n\n" + block + "\n"},
+		{name: "retrait", current: ptr("export A=1\n\n" + managed), want: "export A=1\n"},
+		{name: "retrait sans bloc", current: ptr("export A=1\n"), want: "export A=1\n"},
+	}
+
+	for _, tt := range tests {
+		rcPath := filepath.Join(t.TempDir(), ".bashrc")
+		if tt.current != nil {
+			if err := os.WriteFile(rcPath, []byte(*tt.current), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		edit, err := planRcBlock(rcPath, block, tt.install)
+		if err != nil {
+			t.Fatalf("%s : %v", tt.name, err)
+		}
+		if edit.content != tt.want {
+			t.Errorf("%s : %q, attendu %q", tt.name, edit.content, tt.want)
+		}
+		if edit.exists != (tt.current != nil) {
+			t.Errorf("%s : exists = %v", tt.name, edit.exists)
+		}
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+// Un fichier rc lien symbolique (dotfiles) : la cible est modifiée, le lien conservé
+func TestRcEditApplySymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "bashrc")
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(target, []byte("export A=1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, ".bashrc")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	block := posixSourceBlock(filepath.Join(dir, ".pannel_aliases"))
+	edit, err := planRcBlock(link, block, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edit.path != target {
+		t.Errorf("chemin %s, attendu la cible %s", edit.path, target)
+	}
+	backup, err := edit.apply()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("%s n'est plus un lien symbolique", link)
+	}
+	data, _ := os.ReadFile(link)
+	if string(data) != "export A=1\n\n"+managedRcBlock(block) {
+		t.Errorf("contenu %q", data)
+	}
+	if info, err := os.Stat(target); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("droits de %s non conservés", target)
+	}
+	if filepath.Dir(backup) != filepath.Dir(target) {
+		t.Errorf("sauvegarde %s, attendu à côté de %s", backup, target)
+	}
+	if data, _ := os.ReadFile(backup); string(data) != "export A=1\n" {
+		t.Errorf("sauvegarde %q", data)
+	}
+}
+
+func TestBackupPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".bashrc")
+	now := time.Date(2025, 3, 14, 9, 26, 53, 0, time.Local)
+
+	for _, want := range []string{
+		path + ".pannel-20250314-092653.bak",
+		path + ".pannel-20250314-092653-1.bak",
+		path + ".pannel-20250314-092653-2.bak",
+	} {
+		got := backupPath(path, now)
+		if got != want {
+			t.Fatalf("backupPath = %s, attendu %s", got, want)
+		}
+		if err := os.WriteFile(got, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		before, after string
+		want          string
+	}{
+		{
+			before: "a\nb\nc\nd\ne\nf\ng\nh\ni\n",
+			after:  "a\nB\nc\nd\ne\nf\ng\nh\nI\n",
+			want:   " a\n-b\n+B\n c\n@@\n h\n-i\n+I\n",
+		},
+		{before: "", after: "a\nb\n", want: "+a\n+b\n"},
+		{before: "a\nb\n", after: "", want: "-a\n-b\n"},
+		{before: "a\nb\n", after: "a\nb\n", want: ""},
+		{before: "a\nc\n", after: "a\nb\nc\n", want: " a\n+b\n c\n"},
+	}
+
+	for _, tt := range tests {
+		if got := lineDiff(tt.before, tt.after, 1); got != tt.want {
+			t.Errorf("lineDiff(%q, %q) = %q, attendu %q", tt.before, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestRcEditDiff(t *testing.T) {
+	edit := rcEdit{path: "/home/tom/.bashrc", content: "a\n"}
+	if got, want := edit.diff(), "--- /dev/null\n+++ /home/tom/.bashrc\n+a\n"; got != want {
+		t.Errorf("diff = %q, attendu %q", got, want)
+	}
+	edit = rcEdit{path: "/home/tom/.bashrc", exists: true, current: "a\n", content: "a\n"}
+	if got := edit.diff(); got != "" {
+		t.Errorf("diff sans changement = %q", got)
+	}
+}
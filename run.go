@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Modèle autonome : le plugin occupe tout le terminal, PLUGIN_QUIT termine le programme
+type standaloneModel struct {
+	plugin tea.Model
+}
+
+func (s standaloneModel) Init() tea.Cmd {
+	return s.plugin.Init()
+}
+
+func (s standaloneModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if str, ok := msg.(string); ok && strings.HasPrefix(str, "PLUGIN_QUIT:") {
+		return s, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	s.plugin, cmd = s.plugin.Update(msg)
+	return s, cmd
+}
+
+func (s standaloneModel) View() string {
+	return s.plugin.View()
+}
+
+// Fichiers des plugins installés (sans les manifestes)
+func installedPluginFiles(pluginDir string) ([]string, error) {
+	entries, err := os.ReadDir(pluginDir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) == ".json" {
+			continue
+		}
+		files = append(files, entry.Name())
+	}
+	return files, nil
+}
+
+// Trouver le fichier d'un plugin installé : "foo.so" ou "foo"
+func findInstalledPlugin(pluginDir string, query string) (string, error) {
+	if info, err := os.Stat(filepath.Join(pluginDir, query)); err == nil && !info.IsDir() {
+		return query, nil
+	}
+
+	files, err := installedPluginFiles(pluginDir)
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files {
+		if pluginName(file) == query {
+			return file, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s n'est pas installé", errPluginNotFound, query)
+}
+
+// Demander le consentement dans le terminal (refusé si l'entrée n'est pas interactive)
+func askConsent(consent consentRequest) bool {
+	for _, line := range consent.lines {
+		fmt.Println(line)
+	}
+	fmt.Print("\nAutoriser l'exécution ? [o/N] ")
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		fmt.Println()
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "o" || answer == "oui" || answer == "y" || answer == "yes"
+}
+
+// Pannel run <plugin> [args] : exécuter le TUI d'un plugin installé sans le panel
+func cmdRun(ctx *cliContext, query string, args []string) int {
+	filename, err := findInstalledPlugin(ctx.pluginDir, query)
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	// Même consentement que dans le panel
+	consent, warnings := checkConsent(filename, ctx.pluginDir)
+	for _, err := range warnings {
+		fmt.Fprintf(os.Stderr, "Attention: %v\n", err)
+	}
+	if consent.needed {
+		if !askConsent(consent) {
+			fmt.Fprintf(os.Stderr, "Exécution de %s refusée.\n", filename)
+			return exitError
+		}
+		if err := grantPermission(filename, consent.manifest, ctx.baseDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Attention: impossible d'enregistrer les permissions de %s: %v\n", filename, err)
+		}
+	}
+
+	// Le plugin voit ses arguments comme s'il était lancé directement
+	os.Args = append([]string{pluginName(filename)}, args...)
+
+	msg := loadPluginFile(filepath.Join(ctx.pluginDir, filename), pluginName(filename), ctx.baseDir, args)
+	if msg.err != nil {
+		return exitWithError(msg.err)
+	}
+
+	p := tea.NewProgram(standaloneModel{plugin: msg.model}, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return exitWithError(err)
+	}
+	return exitOK
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Correspondance approximative : les caractères de query apparaissent dans l'ordre dans text, sans tenir
+// compte de la casse. Retourne la position (en runes) de chaque caractère trouvé.
+func fuzzyMatch(query string, text string) ([]int, bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return nil, true
+	}
+
+	var positions []int
+	qi := 0
+	for i, r := range []rune(text) {
+		if unicode.ToLower(r) == q[qi] {
+			positions = append(positions, i)
+			qi++
+			if qi == len(q) {
+				return positions, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Un plugin correspond à la recherche si chaque mot se retrouve dans son nom (approximativement)
+// ou dans la description de son manifeste (tel quel : en approximatif, une description longue
+// correspondrait à presque tout). Retourne les positions trouvées dans le nom, à surligner.
+func matchPlugin(query string, name string, description string) ([]int, bool) {
+	var positions []int
+	for _, term := range strings.Fields(query) {
+		if found, ok := fuzzyMatch(term, name); ok {
+			positions = append(positions, found...)
+			continue
+		}
+		if !strings.Contains(strings.ToLower(description), strings.ToLower(term)) {
+			return nil, false
+		}
+	}
+	return positions, true
+}
+
+// Ligne d'un plugin tronquée à width colonnes, et caractères correspondant à la recherche :
+// positions du nom décalées du préfixe, hors texte remplacé par les points de suspension
+func matchLine(prefix string, name string, positions []int, width int) (string, map[int]bool) {
+	text := prefix + name
+	visible := len([]rune(text))
+	if truncated := truncateText(text, width); truncated != text {
+		text = truncated
+		visible = len([]rune(strings.TrimSuffix(text, ellipsis)))
+	}
+
+	matches := make(map[int]bool)
+	for _, pos := range positions {
+		if pos += len([]rune(prefix)); pos < visible {
+			matches[pos] = true
+		}
+	}
+	return text, matches
+}
+
+// Saisie de la recherche du panel 1 : Entrée garde le filtre, Échap l'efface
+func (m model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.searching = false
+		if strings.TrimSpace(m.searchQuery) == "" {
+			m.searchQuery = ""
+		}
+	case tea.KeyEsc:
+		m.searching = false
+		m.searchQuery = ""
+	case tea.KeyBackspace:
+		if runes := []rune(m.searchQuery); len(runes) > 0 {
+			m.searchQuery = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.searchQuery += string(msg.Runes)
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	default:
+		return m, nil
+	}
+	m.refreshDisplayLines()
+	return m, m.scheduleDetails()
+}
+
+// Reconstruire les lignes affichées (recherche, vue) en gardant le curseur sur la même ligne si elle est encore visible
+func (m *model) refreshDisplayLines() {
+	var current displayLine
+	hasCurrent := m.cursor < len(m.displayLines)
+	if hasCurrent {
+		current = m.displayLines[m.cursor]
+	}
+
+	m.buildDisplayLines()
+
+	if hasCurrent {
+		for i, line := range m.displayLines {
+			if line.repoIdx == current.repoIdx && line.fileIdx == current.fileIdx {
+				m.cursor = i
+				return
+			}
+		}
+	}
+	// Ligne disparue : rester au même endroit de la liste
+	m.cursor = max(0, min(m.cursor, len(m.displayLines)-1))
+}
+
+// Rendre un texte en surlignant les runes aux positions données
+func highlightRunes(text string, positions map[int]bool, base lipgloss.Style, highlight lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(text)
+	}
+
+	var out strings.Builder
+	var run []rune
+	runHighlighted := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if runHighlighted {
+			out.WriteString(highlight.Render(string(run)))
+		} else {
+			out.WriteString(base.Render(string(run)))
+		}
+		run = run[:0]
+	}
+	for i, r := range []rune(text) {
+		if positions[i] != runHighlighted {
+			flush()
+			runHighlighted = positions[i]
+		}
+		run = append(run, r)
+	}
+	flush()
+	return out.String()
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        []int
+		ok          bool
+	}{
+		{query: "jrn", text: "Journal.so", want: []int{0, 3, 4}, ok: true},
+		{query: "JOURNAL", text: "journal.so", want: []int{0, 1, 2, 3, 4, 5, 6}, ok: true},
+		{query: "", text: "Journal.so", ok: true},
+		{query: "ll", text: "Journal.so"},
+		{query: "xyz", text: "Journal.so"},
+		// Casse ignorée sur les lettres accentuées, accents conservés
+		{query: "ÉTÉ", text: "été.so", want: []int{0, 1, 2}, ok: true},
+		{query: "é", text: "Éclair.so", want: []int{0}, ok: true},
+		{query: "RES", text: "Réseau.so", want: []int{0, 3, 7}, ok: true},
+		{query: "rése", text: "Reseau.so"},
+		// Positions en runes
+		{query: "件so", text: "插件管理器.so", want: []int{1, 6, 7}, ok: true},
+	}
+
+	for _, tt := range tests {
+		got, ok := fuzzyMatch(tt.query, tt.text)
+		if ok != tt.ok || !slices.Equal(got, tt.want) {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, %v, attendu %v, %v", tt.query, tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMatchPlugin(t *testing.T) {
+	tests := []struct {
+		query, name, description string
+		want                     []int
+		ok                       bool
+	}{
+		{query: "jo so", name: "Journal.so", want: []int{0, 1, 8, 9}, ok: true},
+		// Chaque mot doit correspondre : au nom, sinon à la description
+		{query: "jour sys", name: "Journal.so", description: "Journal système", want: []int{0, 1, 2, 3}, ok: true},
+		{query: "jour meteo", name: "Journal.so", description: "Journal système"},
+		// Description seule : rien à surligner dans le nom
+		{query: "DISQUE", name: "Outil.so", description: "Analyse du disque", ok: true},
+		{query: "RÉSEAU diag", name: "Outil.so", description: "Diagnostic réseau", ok: true},
+		{query: "reseau", name: "Outil.so", description: "Diagnostic réseau"},
+		// La description n'est pas approximative
+		{query: "dgr", name: "Outil.so", description: "Diagnostic réseau"},
+		{query: "  ", name: "Outil.so", ok: true},
+	}
+
+	for _, tt := range tests {
+		got, ok := matchPlugin(tt.query, tt.name, tt.description)
+		if ok != tt.ok || !slices.Equal(got, tt.want) {
+			t.Errorf("matchPlugin(%q, %q, %q) = %v, %v, attendu %v, %v", tt.query, tt.name, tt.description, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// Surlignage dans la liste : décalé du préfixe, coupé par la troncature
+func TestMatchLine(t *testing.T) {
+	tests := []struct {
+		prefix    string
+		name      string
+		positions []int
+		width     int
+		wantText  string
+		want      []int
+	}{
+		{prefix: "  ", name: "Journal.so", positions: []int{0, 3, 4}, width: 30, wantText: "  Journal.so", want: []int{2, 5, 6}},
+		{prefix: selectionMark + " " + " → ", name: "Journal.so", positions: []int{0, 3, 4}, width: 30, wantText: "✓  → Journal.so", want: []int{5, 8, 9}},
+		{prefix: "  ", name: "Journal.so", positions: []int{0, 3, 4, 8, 9}, width: 8, wantText: "  Jou...", want: []int{2}},
+		{prefix: selectionMark + " ", name: "Journal.so", positions: []int{2, 3}, width: 8, wantText: "✓ Jou...", want: []int{4}},
+		{prefix: "  ", name: "插件管理器.so", positions: []int{0, 1}, width: 8, wantText: "  插...", want: []int{2}},
+		{prefix: "  ", name: "Journal.so", positions: []int{0}, width: 2, wantText: "  ", want: []int{}},
+	}
+
+	for _, tt := range tests {
+		text, matches := matchLine(tt.prefix, tt.name, tt.positions, tt.width)
+		got := slices.Sorted(maps.Keys(matches))
+		if text != tt.wantText || !slices.Equal(got, tt.want) {
+			t.Errorf("matchLine(%q, %q, %v, %d) = %q, %v, attendu %q, %v", tt.prefix, tt.name, tt.positions, tt.width, text, got, tt.wantText, tt.want)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Source des publications par défaut (API GitHub Releases, remplaçable par "release" dans repo.conf ou --source)
+const defaultReleaseURL = "https://api.github.com/repos/TWilhem/GoTUI/releases/latest"
+
+// Fichier des sommes SHA-256 publié avec les binaires (format de sha256sum)
+const checksumsAsset = "checksums.txt"
+
+// Publication de Pannel (format de l'API GitHub Releases)
+type release struct {
+	TagName string         `json:"tag_name"`
+	Assets  []releaseAsset `json:"assets"`
+}
+
+type releaseAsset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// Binaire de Pannel attendu dans la publication pour cette plateforme
+func hostAssetName() string {
+	return fmt.Sprintf("%s-%s-%s", programName, runtime.GOOS, runtime.GOARCH)
+}
+
+func (r release) asset(name string) (releaseAsset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return releaseAsset{}, false
+}
+
+// Source des publications : --source, sinon "release" dans repo.conf, sinon la source par défaut
+func releaseSource(configPath string, source string) (string, error) {
+	if source != "" {
+		return source, nil
+	}
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return defaultReleaseURL, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("erreur lecture repo.conf: %v", err)
+	}
+	var config RepoConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", fmt.Errorf("erreur parsing repo.conf: %v", err)
+	}
+	if config.Release != "" {
+		return config.Release, nil
+	}
+	return defaultReleaseURL, nil
+}
+
+var releaseClient = http.Client{Timeout: 60 * time.Second}
+
+// Télécharger un fichier de la publication
+func fetchReleaseFile(url string) ([]byte, error) {
+	resp, err := releaseClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP %d pour %s", resp.StatusCode, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Dernière publication et sommes de contrôle de ses fichiers
+func fetchRelease(url string) (release, map[string]string, error) {
+	var rel release
+	data, err := fetchReleaseFile(url)
+	if err != nil {
+		return rel, nil, err
+	}
+	if err := json.Unmarshal(data, &rel); err != nil {
+		return rel, nil, fmt.Errorf("publication illisible: %v", err)
+	}
+
+	asset, ok := rel.asset(checksumsAsset)
+	if !ok {
+		return rel, nil, fmt.Errorf("%s absent de la publication %s : mise à jour refusée", checksumsAsset, rel.TagName)
+	}
+	data, err = fetchReleaseFile(asset.DownloadURL)
+	if err != nil {
+		return rel, nil, fmt.Errorf("%s: %v", checksumsAsset, err)
+	}
+	return rel, parseChecksums(data), nil
+}
+
+// Lire un fichier au format de sha256sum ("<somme>  <fichier>"), clé: nom du fichier.
+// Les lignes sans somme SHA-256 valide sont ignorées.
+func parseChecksums(data []byte) map[string]string {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if sum, err := hex.DecodeString(fields[0]); err != nil || len(sum) != sha256.Size {
+			continue
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return sums
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Télécharger un fichier de la publication et vérifier sa somme de contrôle
+func downloadVerified(asset releaseAsset, sums map[string]string) ([]byte, error) {
+	expected, ok := sums[asset.Name]
+	if !ok {
+		return nil, fmt.Errorf("%s absent de %s", asset.Name, checksumsAsset)
+	}
+	data, err := fetchReleaseFile(asset.DownloadURL)
+	if err != nil {
+		return nil, err
+	}
+	if got := sha256Hex(data); got != expected {
+		return nil, fmt.Errorf("somme de contrôle de %s invalide (attendu %s, obtenu %s)", asset.Name, expected, got)
+	}
+	return data, nil
+}
+
+// Numéros d'une version "v1.2.3" (ok = false pour "dev" ou une version illisible)
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	var parts []int
+	for _, field := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
+
+// La publication est plus récente que la version en cours
+func isNewerVersion(latest, current string) bool {
+	l, okLatest := parseVersion(latest)
+	c, okCurrent := parseVersion(current)
+	if !okLatest || !okCurrent {
+		return false
+	}
+	for i := 0; i < max(len(l), len(c)); i++ {
+		var a, b int
+		if i < len(l) {
+			a = l[i]
+		}
+		if i < len(c) {
+			b = c[i]
+		}
+		if a != b {
+			return a > b
+		}
+	}
+	return false
+}
+
+// Remplacer un fichier de façon atomique (fichier temporaire dans le même dossier puis renommage)
+func replaceFile(path string, data []byte, mode os.FileMode) error {
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".new")
+	if err := os.WriteFile(tmp, data, mode); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, mode); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Plugins installés que le nouveau binaire ne pourra plus charger
+func abiWarnings(pluginDir string, newHost []byte) ([]string, error) {
+	host, err := buildinfo.Read(bytes.NewReader(newHost))
+	if err != nil {
+		return nil, fmt.Errorf("informations de compilation de la nouvelle version illisibles: %v", err)
+	}
+
+	files, err := installedPluginFiles(pluginDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var warnings []string
+	for _, file := range files {
+		plugin, err := buildinfo.ReadFile(filepath.Join(pluginDir, file))
+		if err != nil {
+			continue
+		}
+		if mismatches := abiMismatches(host, plugin); len(mismatches) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s (nouvelle version : %s)", file, strings.Join(mismatches, ", ")))
+		}
+	}
+	return warnings, nil
+}
+
+// Pannel self-update [--check] [--force] [--source URL] : mettre à jour Pannel et Chargeur
+func cmdSelfUpdate(ctx *cliContext, source string, checkOnly bool, force bool) int {
+	url, err := releaseSource(ctx.configPath, source)
+	if err != nil {
+		return exitWithError(err)
+	}
+	ctx.logf("source: %s", url)
+
+	rel, sums, err := fetchRelease(url)
+	if err != nil {
+		return exitWithError(err)
+	}
+	fmt.Printf("Version installée : %s, dernière publication : %s\n", version, rel.TagName)
+
+	// --- Pannel : nouvelle version publiée (une version "dev" n'est remplacée qu'avec --force) ---
+	hostAsset, hasHost := rel.asset(hostAssetName())
+	updateHost := hasHost && (force || isNewerVersion(rel.TagName, version))
+	if !hasHost {
+		fmt.Fprintf(os.Stderr, "Attention: aucun binaire %s dans la publication %s\n", hostAssetName(), rel.TagName)
+	} else if !updateHost && version == "dev" {
+		fmt.Println("Version de développement : --force pour la remplacer par la publication.")
+	}
+
+	// --- Chargeur : mis à jour s'il est installé et différent de celui publié ---
+	chargeurFile := filepath.Join(ctx.baseDir, "Chargeur")
+	chargeurAsset, hasChargeur := rel.asset("Chargeur")
+	updateChargeur := false
+	if current, err := os.ReadFile(chargeurFile); err == nil && hasChargeur {
+		if hash, ok := sums[chargeurAsset.Name]; ok {
+			updateChargeur = sha256Hex(current) != hash
+		} else {
+			fmt.Fprintf(os.Stderr, "Attention: Chargeur absent de %s : non mis à jour\n", checksumsAsset)
+		}
+	}
+
+	if !updateHost && !updateChargeur {
+		fmt.Println("Pannel et Chargeur sont à jour.")
+		return exitOK
+	}
+	if checkOnly {
+		if updateHost {
+			fmt.Printf("Pannel : %s → %s disponible\n", version, rel.TagName)
+		}
+		if updateChargeur {
+			fmt.Println("Chargeur : nouvelle version disponible")
+		}
+		return exitOK
+	}
+
+	// Tout télécharger et vérifier avant de remplacer quoi que ce soit
+	var exe string
+	var hostData, chargeurData []byte
+	if updateHost {
+		exe, err = os.Executable()
+		if err == nil {
+			exe, err = filepath.EvalSymlinks(exe)
+		}
+		if err != nil {
+			return exitWithError(fmt.Errorf("emplacement de Pannel introuvable: %v", err))
+		}
+		if hostData, err = downloadVerified(hostAsset, sums); err != nil {
+			return exitWithError(err)
+		}
+	}
+	if updateChargeur {
+		if chargeurData, err = downloadVerified(chargeurAsset, sums); err != nil {
+			return exitWithError(err)
+		}
+	}
+
+	if updateHost {
+		// Les plugins compilés pour l'ancienne version devront être recompilés
+		warnings, err := abiWarnings(ctx.pluginDir, hostData)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Attention: compatibilité des plugins non vérifiée: %v\n", err)
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "Attention: plugin incompatible avec la nouvelle version : %s\n", warning)
+		}
+
+		if err := replaceFile(exe, hostData, 0755); err != nil {
+			return exitWithError(fmt.Errorf("remplacement de %s: %v", exe, err))
+		}
+		fmt.Printf("Pannel mis à jour : %s → %s (%s)\n", version, rel.TagName, exe)
+		if len(warnings) > 0 {
+			fmt.Println("Mettre à jour ces plugins (Pannel update) ou les recompiler avec les dépendances de « Pannel version ».")
+		}
+	}
+
+	if updateChargeur {
+		if err := replaceFile(chargeurFile, chargeurData, 0755); err != nil {
+			return exitWithError(fmt.Errorf("remplacement de %s: %v", chargeurFile, err))
+		}
+		fmt.Printf("Chargeur mis à jour (%s)\n", chargeurFile)
+	}
+	return exitOK
+}
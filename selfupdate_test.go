@@ -0,0 +1,83 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    []int
+		ok      bool
+	}{
+		{version: "v1.2.3", want: []int{1, 2, 3}, ok: true},
+		{version: "1.2.3", want: []int{1, 2, 3}, ok: true},
+		{version: "v2.0", want: []int{2, 0}, ok: true},
+		{version: "v1.4.0-rc.1", want: []int{1, 4, 0}, ok: true},
+		{version: "v1.4.0+build.7", want: []int{1, 4, 0}, ok: true},
+		{version: "dev"},
+		{version: ""},
+		{version: "v1.x.0"},
+		{version: "v1..2"},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseVersion(tt.version)
+		if ok != tt.ok || !slices.Equal(got, tt.want) {
+			t.Errorf("parseVersion(%q) = %v, %v, attendu %v, %v", tt.version, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestIsNewerVersion(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{latest: "v1.3.0", current: "v1.2.9", want: true},
+		{latest: "v1.10.0", current: "v1.9.0", want: true},
+		{latest: "v2.0.0", current: "v1.99.99", want: true},
+		{latest: "v1.2.1", current: "v1.2", want: true},
+		{latest: "v1.2.0", current: "v1.2", want: false},
+		{latest: "v1.2.3", current: "v1.2.3", want: false},
+		{latest: "v1.2.3", current: "v1.3.0", want: false},
+		{latest: "v1.3.0-rc.1", current: "v1.2.0", want: true},
+		// Version de développement ou illisible : jamais remplacée sans --force
+		{latest: "v1.3.0", current: "dev", want: false},
+		{latest: "latest", current: "v1.2.0", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isNewerVersion(tt.latest, tt.current); got != tt.want {
+			t.Errorf("isNewerVersion(%q, %q) = %v, attendu %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	data := []byte(`9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08  Pannel-linux-amd64
+60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752 *Chargeur
+
+mauvaise ligne
+abcd  somme-trop-courte
+fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9  trop  de champs
+`)
+	want := map[string]string{
+		"Pannel-linux-amd64": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		"Chargeur":           "60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752",
+	}
+
+	got := parseChecksums(data)
+	if len(got) != len(want) {
+		t.Errorf("parseChecksums = %v, attendu %v", got, want)
+	}
+	for name, sum := range want {
+		if got[name] != sum {
+			t.Errorf("somme de %s = %q, attendu %q", name, got[name], sum)
+		}
+	}
+	if len(parseChecksums(nil)) != 0 {
+		t.Error("parseChecksums(nil) non vide")
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Shell pris en charge : fichier d'alias dans baseDir, chargé depuis le fichier rc du shell
+type shellSpec struct {
+	name        string
+	aliasFile   string                            // Fichier des alias dans baseDir
+	rcFile      func(home string) string          // Fichier de démarrage du shell
+	alias       func(name, command string) string // Définition d'un alias
+	aliasName   func(line string) (string, bool)  // Nom défini par une ligne du fichier d'alias
+	sourceBlock func(aliasFile string) string     // Bloc chargeant le fichier d'alias
+	header      func(rcFile string) string        // Contenu initial du fichier d'alias
+}
+
+// Syntaxe "alias nom='commande'" (bash et zsh)
+func posixAlias(name, command string) string {
+	return fmt.Sprintf("alias %s='%s'", name, command)
+}
+
+func posixAliasName(line string) (string, bool) {
+	name, _, ok := parseAlias(line)
+	return name, ok
+}
+
+// Bloc de chargement historique, conservé pour reconnaître les installations existantes
+func posixSourceBlock(aliasFile string) string {
+	return fmt.Sprintf(`# Ajout Liste Plugin
+if [ -f %s ]; then 
+    source %s
+fi`, aliasFile, aliasFile)
+}
+
+// Dossier de configuration XDG (fish)
+func configHome(home string) string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	return filepath.Join(home, ".config")
+}
+
+var shells = []shellSpec{
+	{
+		name:        "bash",
+		aliasFile:   ".pluginbashrc",
+		rcFile:      func(home string) string { return filepath.Join(home, ".bashrc") },
+		alias:       posixAlias,
+		aliasName:   posixAliasName,
+		sourceBlock: posixSourceBlock,
+		header: func(rcFile string) string {
+			return "# Plugin bashrc initialisé\n" + "alias Plugin=\"$HOME/.Plugin/Pannel && source ~/.bashrc\"\n"
+		},
+	},
+	{
+		name:      "zsh",
+		aliasFile: ".pluginzshrc",
+		rcFile: func(home string) string {
+			if dir := os.Getenv("ZDOTDIR"); dir != "" {
+				return filepath.Join(dir, ".zshrc")
+			}
+			return filepath.Join(home, ".zshrc")
+		},
+		alias:       posixAlias,
+		aliasName:   posixAliasName,
+		sourceBlock: posixSourceBlock,
+		header: func(rcFile string) string {
+			return "# Plugin zshrc initialisé\n" + fmt.Sprintf("alias Plugin=\"$HOME/.Plugin/Pannel && source %s\"\n", rcFile)
+		},
+	},
+	{
+		name:      "fish",
+		aliasFile: ".plugin.fish",
+		rcFile:    func(home string) string { return filepath.Join(configHome(home), "fish", "config.fish") },
+		alias: func(name, command string) string {
+			return fmt.Sprintf("function %s; %s $argv; end", name, command)
+		},
+		aliasName: func(line string) (string, bool) {
+			definition, ok := strings.CutPrefix(line, "function ")
+			if !ok {
+				return "", false
+			}
+			name, _, ok := strings.Cut(definition, ";")
+			return name, ok
+		},
+		sourceBlock: func(aliasFile string) string {
+			return fmt.Sprintf(`# Ajout Liste Plugin
+if test -f %s
+    source %s
+end`, aliasFile, aliasFile)
+		},
+		header: func(rcFile string) string {
+			return "# Plugin fish initialisé\n" + fmt.Sprintf("function Plugin; $HOME/.Plugin/Pannel; and source %s; end\n", rcFile)
+		},
+	},
+}
+
+// Noms des shells pris en charge
+func shellNames() []string {
+	var names []string
+	for _, shell := range shells {
+		names = append(names, shell.name)
+	}
+	return names
+}
+
+// Trouver un shell par nom
+func findShell(name string) (shellSpec, error) {
+	for _, shell := range shells {
+		if shell.name == name {
+			return shell, nil
+		}
+	}
+	return shellSpec{}, fmt.Errorf("shell non pris en charge: %s (%s)", name, strings.Join(shellNames(), ", "))
+}
+
+// Shell de l'utilisateur d'après $SHELL (bash par défaut)
+func detectShell() shellSpec {
+	if shell, err := findShell(filepath.Base(os.Getenv("SHELL"))); err == nil {
+		return shell
+	}
+	return shells[0]
+}
+
+// Fichier des alias du shell
+func (s shellSpec) aliasPath(baseDir string) string {
+	return filepath.Join(baseDir, s.aliasFile)
+}
+
+// Fichier de démarrage du shell
+func (s shellSpec) rcPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("impossible de récupérer le dossier utilisateur: %v", err)
+	}
+	return s.rcFile(home), nil
+}
+
+// Shells configurés (fichier d'alias présent), à défaut le shell de l'utilisateur
+func activeShells(baseDir string) []shellSpec {
+	var active []shellSpec
+	for _, shell := range shells {
+		if _, err := os.Stat(shell.aliasPath(baseDir)); err == nil {
+			active = append(active, shell)
+		}
+	}
+	if len(active) == 0 {
+		active = append(active, detectShell())
+	}
+	return active
+}
+
+// Créer le fichier des alias avec l'alias d'ouverture du panel
+func (s shellSpec) initAliasFile(baseDir string) error {
+	rcPath, err := s.rcPath()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.aliasPath(baseDir), []byte(s.header(rcPath)), 0644)
+}
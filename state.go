@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// États d'un plugin du dépôt par rapport à l'installation locale
+const (
+	stateAvailable = "available" // Non installé
+	stateInstalled = "installed" // Installé et à jour
+	stateUpdatable = "updatable" // Installé, version distante différente
+	stateOrphaned  = "orphaned"  // Installé, absent des dépôts
+	stateForeign   = "foreign"   // Même nom de fichier installé depuis un autre dépôt
+
+	// États de "status" seulement
+	stateMissing     = "missing"     // Installation enregistrée, fichier local supprimé
+	stateUnreachable = "unreachable" // Dépôt de l'installation injoignable
+)
+
+// Libellés des états pour l'affichage
+var stateLabels = map[string]string{
+	stateAvailable: "disponible",
+	stateInstalled: "installé",
+	stateUpdatable: "mise à jour",
+	stateOrphaned:  "orphelin",
+	stateForeign:   "autre dépôt",
+
+	stateMissing:     "fichier supprimé",
+	stateUnreachable: "dépôt injoignable",
+}
+
+// Plugin installé (baseDir/installed.json)
+type installedPlugin struct {
+	Repo        string    `json:"repo"`
+	SHA         string    `json:"sha"`
+	Size        int64     `json:"size"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// Plugins installés, clé: nom du fichier
+type installedState struct {
+	Plugins map[string]installedPlugin `json:"plugins"`
+}
+
+// Les téléchargements du TUI sont concurrents : un seul accès au fichier à la fois
+var installedMutex sync.Mutex
+
+func installedPath(baseDir string) string {
+	return filepath.Join(baseDir, "installed.json")
+}
+
+// Lire l'état des plugins installés
+func loadInstalled(baseDir string) (installedState, error) {
+	state := installedState{Plugins: make(map[string]installedPlugin)}
+
+	data, err := os.ReadFile(installedPath(baseDir))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("erreur parsing installed.json: %v", err)
+	}
+	if state.Plugins == nil {
+		state.Plugins = make(map[string]installedPlugin)
+	}
+	return state, nil
+}
+
+func saveInstalled(state installedState, baseDir string) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(installedPath(baseDir), data, 0644)
+}
+
+// Enregistrer la version installée d'un plugin
+func recordInstall(repo Repository, file GitHubFile, baseDir string) error {
+	installedMutex.Lock()
+	defer installedMutex.Unlock()
+
+	state, err := loadInstalled(baseDir)
+	if err != nil {
+		return err
+	}
+	state.Plugins[file.Name] = installedPlugin{
+		Repo:        repo.Name,
+		SHA:         file.SHA,
+		Size:        file.Size,
+		InstalledAt: time.Now().UTC(),
+	}
+	return saveInstalled(state, baseDir)
+}
+
+// Oublier un plugin supprimé
+func forgetInstall(filename string, baseDir string) error {
+	installedMutex.Lock()
+	defer installedMutex.Unlock()
+
+	state, err := loadInstalled(baseDir)
+	if err != nil {
+		return err
+	}
+	if _, ok := state.Plugins[filename]; !ok {
+		return nil
+	}
+	delete(state.Plugins, filename)
+	return saveInstalled(state, baseDir)
+}
+
+// État d'un fichier du dépôt (un plugin présent sans version connue est à mettre à jour)
+func pluginState(repo Repository, file GitHubFile, local bool, state installedState) string {
+	installed, ok := state.Plugins[file.Name]
+	if ok && installed.Repo != "" && installed.Repo != repo.Name {
+		return stateForeign
+	}
+	if !local {
+		return stateAvailable
+	}
+	if !ok || installed.SHA == "" || (file.SHA != "" && installed.SHA != file.SHA) {
+		return stateUpdatable
+	}
+	return stateInstalled
+}
+
+// Clé d'un plugin d'un dépôt dans les fichiers locaux
+func localKey(repoName string, filename string) string {
+	return repoName + "/" + filename
+}
+
+// Vérifier quels fichiers des dépôts existent localement (clé: localKey).
+// Un fichier installé depuis un autre dépôt ne compte pas pour celui-ci.
+func localPluginFiles(repos []Repository, pluginDir string, state installedState) map[string]bool {
+	localFiles := make(map[string]bool)
+	for _, repo := range repos {
+		for _, file := range repo.Files {
+			if other := state.Plugins[file.Name].Repo; other != "" && other != repo.Name {
+				continue
+			}
+			filePath := filepath.Join(pluginDir, file.Name)
+			if _, err := os.Stat(filePath); err == nil {
+				localFiles[localKey(repo.Name, file.Name)] = true
+			}
+		}
+	}
+	return localFiles
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPluginState(t *testing.T) {
+	plugin := Repository{Name: "TWilhem/Plugin"}
+	other := Repository{Name: "AutreUser/AutreRepo"}
+	journal := GitHubFile{Name: "Journal.so", SHA: "1111", Size: 4096}
+	state := installedState{Plugins: map[string]installedPlugin{
+		"Journal.so": {Repo: "TWilhem/Plugin", SHA: "1111", Size: 4096},
+		"Reseau.so":  {Repo: "TWilhem/Plugin", SHA: "aaaa", Size: 8000},
+		"Ancien.so":  {SHA: "5555"},
+	}}
+
+	tests := []struct {
+		repo  Repository
+		file  GitHubFile
+		local bool
+		want  string
+	}{
+		{repo: plugin, file: journal, local: true, want: stateInstalled},
+		{repo: plugin, file: journal, local: false, want: stateAvailable},
+		{repo: plugin, file: GitHubFile{Name: "Reseau.so", SHA: "2222", Size: 8192}, local: true, want: stateUpdatable},
+		{repo: plugin, file: GitHubFile{Name: "Disque.so"}, local: true, want: stateUpdatable},
+		{repo: plugin, file: GitHubFile{Name: "Disque.so"}, local: false, want: stateAvailable},
+		// Même nom de fichier installé depuis un autre dépôt
+		{repo: other, file: journal, local: true, want: stateForeign},
+		{repo: other, file: journal, local: false, want: stateForeign},
+		{repo: other, file: GitHubFile{Name: "Reseau.so", SHA: "aaaa", Size: 8000}, local: true, want: stateForeign},
+		// Installation antérieure sans dépôt enregistré
+		{repo: other, file: GitHubFile{Name: "Ancien.so", SHA: "5555"}, local: true, want: stateInstalled},
+	}
+
+	for _, tt := range tests {
+		if got := pluginState(tt.repo, tt.file, tt.local, state); got != tt.want {
+			t.Errorf("pluginState(%s, %s, %v) = %q, attendu %q", tt.repo.Name, tt.file.Name, tt.local, got, tt.want)
+		}
+	}
+}
+
+func TestLocalPluginFiles(t *testing.T) {
+	pluginDir := t.TempDir()
+	for _, name := range []string{"Journal.so", "Reseau.so", "Ancien.so"} {
+		if err := os.WriteFile(filepath.Join(pluginDir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repos := []Repository{
+		{Name: "TWilhem/Plugin", Files: []GitHubFile{{Name: "Journal.so"}, {Name: "Reseau.so"}, {Name: "Disque.so"}}},
+		{Name: "AutreUser/AutreRepo", Files: []GitHubFile{{Name: "Journal.so"}, {Name: "Ancien.so"}}},
+	}
+	state := installedState{Plugins: map[string]installedPlugin{
+		"Journal.so": {Repo: "TWilhem/Plugin"},
+		"Reseau.so":  {Repo: "TWilhem/Plugin"},
+	}}
+
+	got := localPluginFiles(repos, pluginDir, state)
+	want := map[string]bool{
+		"TWilhem/Plugin/Journal.so":     true,
+		"TWilhem/Plugin/Reseau.so":      true,
+		"AutreUser/AutreRepo/Ancien.so": true,
+	}
+	if len(got) != len(want) {
+		t.Errorf("localPluginFiles = %v, attendu %v", got, want)
+	}
+	for key := range want {
+		if !got[key] {
+			t.Errorf("%s absent de %v", key, got)
+		}
+	}
+}
+
+// Les opérations d'un lot se terminent dans n'importe quel ordre : chacune recharge installed.json
+func TestOperationCompleteCounts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	baseDir := t.TempDir()
+	m := initialModel(baseDir, filepath.Join(baseDir, "repo.conf"))
+	repo := Repository{Name: "TWilhem/Plugin", Files: []GitHubFile{
+		{Name: "Journal.so", SHA: "1111", Size: 4096},
+		{Name: "Reseau.so", SHA: "2222", Size: 8192},
+	}}
+	m.repos = []Repository{repo}
+	m.loading = false
+
+	// Écrit par downloadPlugin avant son message
+	if err := os.WriteFile(filepath.Join(m.pluginDir, "Journal.so"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordInstall(repo, repo.Files[0], baseDir); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, _ := m.Update(operationCompleteMsg{filename: "Journal.so", repo: repo.Name, operation: "download"})
+	m = updated.(model)
+	counts := m.viewCounts()
+	if counts[viewInstalled] != 1 || counts[viewUpdatable] != 0 || counts[viewAvailable] != 1 {
+		t.Errorf("après installation : %d installé(s), %d à mettre à jour, %d disponible(s), attendu 1, 0, 1",
+			counts[viewInstalled], counts[viewUpdatable], counts[viewAvailable])
+	}
+
+	if err := os.Remove(filepath.Join(m.pluginDir, "Journal.so")); err != nil {
+		t.Fatal(err)
+	}
+	if err := forgetInstall("Journal.so", baseDir); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ = m.Update(operationCompleteMsg{filename: "Journal.so", operation: "delete"})
+	m = updated.(model)
+	counts = m.viewCounts()
+	if counts[viewInstalled] != 0 || counts[viewUpdatable] != 0 || counts[viewAvailable] != 2 {
+		t.Errorf("après suppression : %d installé(s), %d à mettre à jour, %d disponible(s), attendu 0, 0, 2",
+			counts[viewInstalled], counts[viewUpdatable], counts[viewAvailable])
+	}
+}
+
+// Un lot n'est terminé qu'au dernier résultat, quel que soit l'ordre d'arrivée
+func TestOperationCompletePending(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	baseDir := t.TempDir()
+	m := initialModel(baseDir, filepath.Join(baseDir, "repo.conf"))
+	m.loading = false
+	m.processing = true
+	m.pending = 2
+
+	fail := errors.New("réseau indisponible")
+	updated, cmd := m.Update(operationCompleteMsg{filename: "Reseau.so", operation: "download", err: fail})
+	m = updated.(model)
+	if cmd != nil || !m.processing || m.pending != 1 {
+		t.Fatalf("après 1/2 : commande %v, traitement %v, %d en attente", cmd != nil, m.processing, m.pending)
+	}
+
+	updated, cmd = m.Update(operationCompleteMsg{filename: "Journal.so", operation: "download", err: fail})
+	m = updated.(model)
+	if cmd == nil || m.pending != 0 {
+		t.Fatalf("après 2/2 : commande %v, %d en attente", cmd != nil, m.pending)
+	}
+	if _, ok := cmd().(allOperationsCompleteMsg); !ok {
+		t.Fatal("fin du lot non signalée")
+	}
+}
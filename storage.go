@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Nom d'un plugin (nom du fichier sans extension)
+func pluginName(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename))
+}
+
+// Dossier des données persistantes d'un plugin : baseDir/data/<plugin>
+func pluginDataDir(name string, baseDir string) string {
+	return filepath.Join(baseDir, "data", name)
+}
+
+// Vérifier si un plugin a des données enregistrées
+func hasPluginData(name string, baseDir string) bool {
+	entries, err := os.ReadDir(pluginDataDir(name, baseDir))
+	return err == nil && len(entries) > 0
+}
+
+// Supprimer les données d'un plugin
+func purgePluginData(name string, baseDir string) error {
+	return os.RemoveAll(pluginDataDir(name, baseDir))
+}
+
+// Stockage clé/valeur d'un plugin (baseDir/data/<plugin>/store.json)
+type pluginStore struct {
+	dir  string
+	path string
+	mu   sync.Mutex
+}
+
+// Ouvrir (et créer si besoin) le stockage d'un plugin
+func openPluginStore(name string, baseDir string) (*pluginStore, error) {
+	dir := pluginDataDir(name, baseDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &pluginStore{dir: dir, path: filepath.Join(dir, "store.json")}, nil
+}
+
+// Lire toutes les valeurs (appelant verrouillé)
+func (s *pluginStore) load() (map[string]string, error) {
+	values := make(map[string]string)
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Écrire toutes les valeurs via un fichier temporaire (appelant verrouillé)
+func (s *pluginStore) save(values map[string]string) error {
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func (s *pluginStore) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.load()
+	if err != nil {
+		return "", false
+	}
+	value, ok := values[key]
+	return value, ok
+}
+
+func (s *pluginStore) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.load()
+	if err != nil {
+		return err
+	}
+	values[key] = value
+	return s.save(values)
+}
+
+func (s *pluginStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := values[key]; !ok {
+		return nil
+	}
+	delete(values, key)
+	return s.save(values)
+}
+
+func (s *pluginStore) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.load()
+	if err != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// API de l'hôte transmise aux plugins exportant SetHost(map[string]any).
+// Uniquement des types de la bibliothèque standard pour ne pas lier le plugin à Pannel.
+func hostAPI(store *pluginStore, args []string) map[string]any {
+	return map[string]any{
+		"Args":    args,
+		"DataDir": store.dir,
+		"Get":     store.Get,
+		"Set":     store.Set,
+		"Delete":  store.Delete,
+		"Keys":    store.Keys,
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Thème par défaut : sombre ou clair selon le fond du terminal
+const themeAuto = "auto"
+
+// Couleurs de l'interface (code ANSI "0" à "255" ou "#rrggbb" ; "" : couleur du terminal)
+type theme struct {
+	Base          string         `json:"base,omitempty"` // Thème de départ d'un thème de repo.conf (défaut : auto)
+	Accent        lipgloss.Color `json:"accent,omitempty"`
+	Muted         lipgloss.Color `json:"muted,omitempty"`
+	Text          lipgloss.Color `json:"text,omitempty"`
+	Selection     lipgloss.Color `json:"selection,omitempty"` // "" : ligne du curseur en vidéo inverse
+	SelectionText lipgloss.Color `json:"selection_text,omitempty"`
+	Available     lipgloss.Color `json:"available,omitempty"`
+	Installed     lipgloss.Color `json:"installed,omitempty"`
+	Remove        lipgloss.Color `json:"remove,omitempty"`
+	Warning       lipgloss.Color `json:"warning,omitempty"`
+
+	name     string
+	renderer *lipgloss.Renderer // nil : rendu par défaut de lipgloss
+}
+
+// Thèmes intégrés, dans l'ordre de l'aperçu
+var builtinThemes = []struct {
+	name  string
+	label string
+	theme theme
+}{
+	{"dark", "Sombre", theme{Accent: "86", Muted: "240", Text: "15", Selection: "240", SelectionText: "15",
+		Available: "39", Installed: "2", Remove: "1", Warning: "11"}},
+	{"light", "Clair", theme{Accent: "30", Muted: "246", Text: "0", Selection: "252", SelectionText: "0",
+		Available: "26", Installed: "28", Remove: "160", Warning: "130"}},
+	// Couleurs de base du terminal et vidéo inverse : lisible sur tout fond
+	{"high-contrast", "Contraste élevé", theme{Accent: "12", Available: "4", Installed: "2", Remove: "1", Warning: "3"}},
+	// Aucune couleur, seulement gras, inverse et barré (NO_COLOR, --no-color)
+	{"mono", "Sans couleur", theme{}},
+}
+
+// Noms des thèmes intégrés (complétion)
+func builtinThemeNames() []string {
+	names := []string{themeAuto}
+	for _, b := range builtinThemes {
+		names = append(names, b.name)
+	}
+	return names
+}
+
+// Rôle d'une couleur : clé dans repo.conf et usage (aperçu)
+type themeRole struct {
+	key   string
+	usage string
+	color *lipgloss.Color
+}
+
+func (t *theme) roles() []themeRole {
+	return []themeRole{
+		{"accent", "panel actif, titres, touches", &t.Accent},
+		{"muted", "panels inactifs, libellés", &t.Muted},
+		{"text", "barre de statut, dépôts", &t.Text},
+		{"selection", "fond de la ligne du curseur", &t.Selection},
+		{"selection_text", "texte de la ligne du curseur", &t.SelectionText},
+		{"available", "plugin disponible", &t.Available},
+		{"installed", "plugin installé ou à installer", &t.Installed},
+		{"remove", "plugin à supprimer, dépôt bloqué", &t.Remove},
+		{"warning", "dépôt non signé, avertissements", &t.Warning},
+	}
+}
+
+// Nouveau style rendu avec le profil du thème
+func (t theme) style() lipgloss.Style {
+	if t.renderer != nil {
+		return t.renderer.NewStyle()
+	}
+	return lipgloss.NewStyle()
+}
+
+// Couleur de la bordure d'un panel
+func (t theme) border(active bool) lipgloss.Color {
+	if active {
+		return t.Accent
+	}
+	return t.Muted
+}
+
+// Style de la ligne du curseur
+func (t theme) selected() lipgloss.Style {
+	if t.Selection == "" {
+		return t.style().Reverse(true)
+	}
+	return t.style().Background(t.Selection).Foreground(t.SelectionText)
+}
+
+// Code ANSI (0-255) ou couleur hexadécimale (#rgb, #rrggbb)
+func validColor(c lipgloss.Color) bool {
+	s := string(c)
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// Couleurs désactivées (NO_COLOR, --no-color ou sortie hors terminal)
+func colorDisabled() bool {
+	return lipgloss.ColorProfile() == termenv.Ascii
+}
+
+// Thème sans couleur ; sur un terminal, gras, inverse et barré restent affichés (NO_COLOR n'interdit que les couleurs)
+func monoTheme() theme {
+	t, _ := builtinTheme("mono")
+	t.renderer = lipgloss.NewRenderer(os.Stdout)
+	if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		t.renderer.SetColorProfile(termenv.ANSI)
+	} else {
+		t.renderer.SetColorProfile(termenv.Ascii)
+	}
+	return t
+}
+
+func builtinTheme(name string) (theme, bool) {
+	if name == themeAuto {
+		name = "light"
+		if lipgloss.HasDarkBackground() {
+			name = "dark"
+		}
+	}
+	for _, b := range builtinThemes {
+		if b.name == name {
+			t := b.theme
+			t.name = name
+			return t, true
+		}
+	}
+	return theme{}, false
+}
+
+// Thème nommé : intégré, ou de repo.conf complété par son thème de départ
+func findTheme(name string, custom map[string]theme) (theme, []string, error) {
+	user, ok := custom[name]
+	if !ok {
+		if t, ok := builtinTheme(name); ok {
+			return t, nil, nil
+		}
+		return theme{}, nil, fmt.Errorf("thème inconnu: %s", name)
+	}
+
+	var warnings []string
+	base := user.Base
+	if base == "" {
+		base = themeAuto
+	}
+	t, ok := builtinTheme(base)
+	if !ok {
+		warnings = append(warnings, fmt.Sprintf("thème de départ inconnu pour %s: %s, thème auto utilisé", name, base))
+		t, _ = builtinTheme(themeAuto)
+	}
+
+	overrides := user.roles()
+	for i, role := range t.roles() {
+		color := *overrides[i].color
+		switch {
+		case color == "":
+			continue
+		case !validColor(color):
+			warnings = append(warnings, fmt.Sprintf("couleur invalide pour %s du thème %s: %q", role.key, name, color))
+			continue
+		}
+		*role.color = color
+	}
+	t.name = name
+	return t, warnings, nil
+}
+
+// Sections "theme" et "themes" de repo.conf (vides si le fichier est absent ou invalide)
+func readThemeConfig(configPath string) RepoConfig {
+	var config RepoConfig
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return config
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return RepoConfig{}
+	}
+	return config
+}
+
+// Thème de l'interface : celui de repo.conf, ou mono si les couleurs sont désactivées
+func loadTheme(configPath string) (theme, []string) {
+	if colorDisabled() {
+		return monoTheme(), nil
+	}
+	return configTheme(configPath)
+}
+
+// Thème choisi dans repo.conf ({"theme": "light"} ou un thème de la section "themes").
+// En cas d'erreur, le thème auto est gardé ; les avertissements sont retournés.
+func configTheme(configPath string) (theme, []string) {
+	config := readThemeConfig(configPath)
+	name := config.Theme
+	if name == "" {
+		name = themeAuto
+	}
+
+	t, warnings, err := findTheme(name, config.Themes)
+	if err != nil {
+		t, _ = builtinTheme(themeAuto)
+		warnings = append(warnings, err.Error()+", thème auto utilisé")
+	}
+	return t, warnings
+}
+
+// Aperçu d'un thème : couleurs de chaque rôle et extrait du panel des dépôts
+func (t theme) preview(title string) string {
+	var lines []string
+	for _, role := range t.roles() {
+		swatch := t.style().Foreground(*role.color).Render("██")
+		if role.key == "selection" {
+			swatch = t.selected().Render("  ")
+		}
+		value := string(*role.color)
+		if value == "" {
+			value = "terminal"
+		}
+		lines = append(lines, fmt.Sprintf("%s %-15s %-9s %s", swatch, role.key, value, role.usage))
+	}
+
+	sample := []string{
+		t.style().Foreground(t.Text).Render("▼ ✓ TWilhem/Plugin"),
+		t.selected().Render(padText("  Journal.so", 24)),
+		t.style().Foreground(t.Available).Render("  Meteo.so"),
+		t.style().Foreground(t.Remove).Render("✓ Reseau.so"),
+		t.style().Foreground(t.Warning).Render("▼ ! AutreUser/NonSigne"),
+		t.style().Foreground(t.Remove).Strikethrough(true).Render("▼ ⨯ AutreUser/Bloque"),
+	}
+
+	return t.style().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Accent).
+		Padding(0, 1).
+		Render(t.style().Bold(true).Foreground(t.Accent).Render(title) + "\n\n" +
+			strings.Join(lines, "\n") + "\n\n" + strings.Join(sample, "\n"))
+}
+
+// Pannel theme [nom] : aperçu des thèmes (intégrés et de repo.conf), ou d'un seul
+func cmdTheme(ctx *cliContext, name string) int {
+	current, warnings := loadTheme(ctx.configPath)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Attention: %s\n", warning)
+	}
+
+	config := readThemeConfig(ctx.configPath)
+	names := make([]string, 0, len(builtinThemes)+len(config.Themes))
+	for _, b := range builtinThemes {
+		names = append(names, b.name)
+	}
+	custom := make([]string, 0, len(config.Themes))
+	for n := range config.Themes {
+		custom = append(custom, n)
+	}
+	sort.Strings(custom)
+	names = append(names, custom...)
+
+	if name != "" {
+		names = []string{name}
+	}
+	for i, n := range names {
+		t, themeWarnings, err := findTheme(n, config.Themes)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Erreur: %v\n", err)
+			return exitNotFound
+		}
+		// Thème actif : avertissements déjà affichés, rendu de l'interface (attributs gardés sans couleur)
+		if n == current.name {
+			t = current
+		} else if name != "" {
+			for _, warning := range themeWarnings {
+				fmt.Fprintf(os.Stderr, "Attention: %s\n", warning)
+			}
+		}
+
+		title := n
+		for _, b := range builtinThemes {
+			if b.name == n {
+				title += " — " + b.label
+			}
+		}
+		if n == current.name {
+			title += " (actif)"
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Println(t.preview(title))
+	}
+	return exitOK
+}
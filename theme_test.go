@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestFindTheme(t *testing.T) {
+	custom := map[string]theme{
+		"ocean":   {Base: "light", Accent: "#1e90ff", Remove: "300"},
+		"inconnu": {Base: "solarized", Warning: "208"},
+	}
+
+	ocean, warnings, err := findTheme("ocean", custom)
+	if err != nil {
+		t.Fatalf("findTheme(ocean): %v", err)
+	}
+	light, _ := builtinTheme("light")
+	if ocean.Accent != "#1e90ff" || ocean.Remove != light.Remove || ocean.Muted != light.Muted {
+		t.Errorf("ocean = %+v, attendu light avec accent #1e90ff", ocean)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("avertissements %q, attendu la couleur invalide de remove", warnings)
+	}
+
+	other, warnings, _ := findTheme("inconnu", custom)
+	if other.Warning != "208" || len(warnings) != 1 {
+		t.Errorf("thème de départ inconnu : %+v, %q", other, warnings)
+	}
+
+	if _, _, err := findTheme("solarized", custom); err == nil {
+		t.Error("findTheme(solarized) sans erreur")
+	}
+}
+
+func TestValidColor(t *testing.T) {
+	for color, want := range map[lipgloss.Color]bool{
+		"0": true, "86": true, "255": true, "#fff": true, "#1E90FF": true,
+		"256": false, "-1": false, "#12345": false, "#gggggg": false, "bleu": false,
+	} {
+		if got := validColor(color); got != want {
+			t.Errorf("validColor(%q) = %v, attendu %v", color, got, want)
+		}
+	}
+}
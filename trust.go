@@ -0,0 +1,134 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Politiques pour les dépôts sans clé de signature
+const (
+	policyWarn  = "warn"  // Installer en avertissant (par défaut)
+	policyBlock = "block" // Refuser l'installation
+)
+
+// Statut de confiance d'un dépôt
+const (
+	trustSigned     = "signed"      // Clé déclarée
+	trustUnsigned   = "unsigned"    // Sans clé
+	trustBlocked    = "blocked"     // Sans clé, refusé par la politique
+	trustInvalidKey = "invalid_key" // Clé déclarée mais illisible : dépôt bloqué
+)
+
+// Libellés des statuts de confiance pour l'affichage
+var trustLabels = map[string]string{
+	trustSigned:     "signé",
+	trustUnsigned:   "non signé",
+	trustBlocked:    "bloqué",
+	trustInvalidKey: "clé invalide",
+}
+
+// Extension des signatures publiées à côté des fichiers (<fichier>.sig)
+const signatureExt = ".sig"
+
+// Vérification de signature d'un téléchargement
+type signatureCheck struct {
+	publicKey string      // Clé ed25519 du dépôt (base64)
+	signature *GitHubFile // Signature publiée (nil si absente)
+}
+
+// Décoder une clé publique ed25519 encodée en base64
+func parsePublicKey(encoded string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("clé publique invalide: %v", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("clé publique invalide: %d octets au lieu de %d", len(key), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// Séparer les signatures (<fichier>.sig) du contenu d'un dépôt
+func splitSignatures(files []GitHubFile) ([]GitHubFile, map[string]GitHubFile) {
+	names := make(map[string]bool)
+	for _, file := range files {
+		names[file.Name] = true
+	}
+
+	var others []GitHubFile
+	signatures := make(map[string]GitHubFile)
+	for _, file := range files {
+		signed := strings.TrimSuffix(file.Name, signatureExt)
+		if strings.HasSuffix(file.Name, signatureExt) && names[signed] {
+			signatures[signed] = file
+			continue
+		}
+		others = append(others, file)
+	}
+	return others, signatures
+}
+
+// Statut de confiance d'un dépôt
+func repoTrust(repo Repository) string {
+	if repo.KeyError != nil {
+		return trustInvalidKey
+	}
+	if repo.Blocked {
+		return trustBlocked
+	}
+	if repo.PublicKey == "" {
+		return trustUnsigned
+	}
+	return trustSigned
+}
+
+// Vérification à appliquer aux fichiers d'un dépôt (nil si le dépôt n'a pas de clé)
+func (r Repository) signatureCheckFor(file GitHubFile) *signatureCheck {
+	if r.PublicKey == "" {
+		return nil
+	}
+	check := &signatureCheck{publicKey: r.PublicKey}
+	if signature, ok := r.Signatures[file.Name]; ok {
+		check.signature = &signature
+	}
+	return check
+}
+
+// Vérifier le contenu téléchargé avec la signature publiée
+func (c *signatureCheck) verify(name string, content []byte) error {
+	key, err := parsePublicKey(c.publicKey)
+	if err != nil {
+		return err
+	}
+	if c.signature == nil || c.signature.DownloadURL == "" {
+		return fmt.Errorf("signature %s%s absente du dépôt", name, signatureExt)
+	}
+
+	resp, err := http.Get(c.signature.DownloadURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("HTTP %d pour %s", resp.StatusCode, c.signature.DownloadURL)
+	}
+
+	encoded, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return fmt.Errorf("signature %s illisible: %v", name, err)
+	}
+
+	if !ed25519.Verify(key, content, signature) {
+		return fmt.Errorf("signature invalide pour %s", name)
+	}
+	return nil
+}
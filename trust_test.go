@@ -0,0 +1,165 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Paire de clés déterministe pour les tests
+func testKey(seed byte) (ed25519.PrivateKey, string) {
+	private := ed25519.NewKeyFromSeed([]byte(strings.Repeat(string(rune(seed)), ed25519.SeedSize)))
+	return private, base64.StdEncoding.EncodeToString(private.Public().(ed25519.PublicKey))
+}
+
+func TestSignatureVerify(t *testing.T) {
+	content := []byte("contenu de Journal.so")
+	private, publicKey := testKey('a')
+	_, otherKey := testKey('b')
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(private, content))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Journal.so.sig":
+			w.Write([]byte(signature + "\n"))
+		case "/illisible.sig":
+			w.Write([]byte("pas du base64 !"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	sig := func(path string) *GitHubFile {
+		return &GitHubFile{Name: "Journal.so.sig", DownloadURL: server.URL + path}
+	}
+
+	tests := []struct {
+		name    string
+		check   signatureCheck
+		content []byte
+		err     string // Extrait du message attendu ("" : vérification réussie)
+	}{
+		{name: "signature valide", check: signatureCheck{publicKey: publicKey, signature: sig("/Journal.so.sig")}, content: content},
+		{name: "contenu modifié", check: signatureCheck{publicKey: publicKey, signature: sig("/Journal.so.sig")}, content: []byte("contenu modifié"), err: "signature invalide"},
+		{name: "signature absente", check: signatureCheck{publicKey: publicKey}, content: content, err: "absente du dépôt"},
+		{name: "signature introuvable", check: signatureCheck{publicKey: publicKey, signature: sig("/absente.sig")}, content: content, err: "HTTP 404"},
+		{name: "signature illisible", check: signatureCheck{publicKey: publicKey, signature: sig("/illisible.sig")}, content: content, err: "illisible"},
+		{name: "mauvaise clé", check: signatureCheck{publicKey: otherKey, signature: sig("/Journal.so.sig")}, content: content, err: "signature invalide"},
+		{name: "clé mal formée", check: signatureCheck{publicKey: "q0cBqP9m", signature: sig("/Journal.so.sig")}, content: content, err: "clé publique invalide"},
+	}
+
+	for _, tt := range tests {
+		err := tt.check.verify("Journal.so", tt.content)
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s: erreur inattendue: %v", tt.name, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("%s: erreur %v, attendu %q", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	_, valid := testKey('a')
+	tests := []struct {
+		key string
+		ok  bool
+	}{
+		{key: valid, ok: true},
+		{key: " " + valid + "\n", ok: true},
+		{key: "", ok: false},
+		{key: "pas du base64 !", ok: false},
+		{key: base64.StdEncoding.EncodeToString([]byte("trop courte")), ok: false},
+		{key: base64.StdEncoding.EncodeToString(make([]byte, ed25519.PublicKeySize+1)), ok: false},
+	}
+
+	for _, tt := range tests {
+		key, err := parsePublicKey(tt.key)
+		if (err == nil) != tt.ok {
+			t.Errorf("parsePublicKey(%q) erreur = %v", tt.key, err)
+		}
+		if tt.ok && len(key) != ed25519.PublicKeySize {
+			t.Errorf("parsePublicKey(%q) = %d octets", tt.key, len(key))
+		}
+	}
+}
+
+func TestSplitSignatures(t *testing.T) {
+	files := []GitHubFile{
+		{Name: "Journal.so"},
+		{Name: "Journal.so.sig"},
+		{Name: "Journal.json"},
+		{Name: "Journal.json.sig"},
+		{Name: "Orphelin.so.sig"}, // Fichier signé absent : gardé tel quel
+		{Name: "Reseau.so"},
+	}
+
+	others, signatures := splitSignatures(files)
+
+	var names []string
+	for _, file := range others {
+		names = append(names, file.Name)
+	}
+	if got := strings.Join(names, " "); got != "Journal.so Journal.json Orphelin.so.sig Reseau.so" {
+		t.Errorf("fichiers = %s", got)
+	}
+	if len(signatures) != 2 || signatures["Journal.so"].Name != "Journal.so.sig" || signatures["Journal.json"].Name != "Journal.json.sig" {
+		t.Errorf("signatures = %v", signatures)
+	}
+}
+
+func TestRepoTrust(t *testing.T) {
+	_, err := parsePublicKey("q0cBqP9m")
+	tests := []struct {
+		repo Repository
+		want string
+	}{
+		{repo: Repository{PublicKey: "q0cBqP9m4C5QSkPjM9k2o8v3m3t2YQ1Jv1XcJ0q3x9E="}, want: trustSigned},
+		{repo: Repository{}, want: trustUnsigned},
+		{repo: Repository{Blocked: true}, want: trustBlocked},
+		{repo: Repository{PublicKey: "q0cBqP9m", Blocked: true, KeyError: err}, want: trustInvalidKey},
+	}
+	for _, tt := range tests {
+		if got := repoTrust(tt.repo); got != tt.want {
+			t.Errorf("repoTrust(%+v) = %s, attendu %s", tt.repo, got, tt.want)
+		}
+	}
+}
+
+func TestFetchFilesKeyCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"name": "Journal.so", "type": "file"}]`))
+	}))
+	defer server.Close()
+
+	_, valid := testKey('a')
+	config := fmt.Sprintf(`{"repos": [
+		{"name": "Signé", "url": %q, "public_key": %q},
+		{"name": "Clé tronquée", "url": %q, "public_key": "q0cBqP9m"},
+		{"name": "Non signé", "url": %q}
+	]}`, server.URL, valid, server.URL, server.URL)
+	configPath := filepath.Join(t.TempDir(), "repo.conf")
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := fetchFiles(configPath)().(filesLoadedMsg)
+	if msg.err != nil || len(msg.repos) != 3 {
+		t.Fatalf("fetchFiles: %d dépôt(s), %v", len(msg.repos), msg.err)
+	}
+	for i, want := range []string{trustSigned, trustInvalidKey, trustUnsigned} {
+		if got := repoTrust(msg.repos[i]); got != want {
+			t.Errorf("%s: %s, attendu %s", msg.repos[i].Name, got, want)
+		}
+	}
+	if !msg.repos[1].Blocked {
+		t.Error("dépôt à la clé tronquée non bloqué")
+	}
+}
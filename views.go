@@ -0,0 +1,67 @@
+package main
+
+import "fmt"
+
+// Vues du panel 1, parcourues avec "v"
+const (
+	viewAll       = iota // Tous les plugins
+	viewInstalled        // Installés
+	viewAvailable        // Non installés
+	viewUpdatable        // Mise à jour disponible
+	viewSelected         // Sélectionnés
+	viewCount
+)
+
+// Libellés des vues (bordure du panel 1)
+var viewLabels = [viewCount]string{
+	viewAll:       "Repositories",
+	viewInstalled: "Installés",
+	viewAvailable: "Non installés",
+	viewUpdatable: "Mises à jour",
+	viewSelected:  "Sélection",
+}
+
+// Le plugin d'un dépôt appartient à la vue
+func (m model) inView(view int, repoIdx int, fileIdx int) bool {
+	repo := m.repos[repoIdx]
+	file := repo.Files[fileIdx]
+	local := m.localFiles[localKey(repo.Name, file.Name)]
+	switch view {
+	case viewInstalled:
+		return local
+	case viewAvailable:
+		return !local && pluginState(repo, file, local, m.installed) == stateAvailable
+	case viewUpdatable:
+		return pluginState(repo, file, local, m.installed) == stateUpdatable
+	case viewSelected:
+		return m.selected[fmt.Sprintf("%d:%d", repoIdx, fileIdx)]
+	}
+	return true
+}
+
+// Nombre de plugins de chaque vue (sans tenir compte de la recherche)
+func (m model) viewCounts() [viewCount]int {
+	var counts [viewCount]int
+	for repoIdx, repo := range m.repos {
+		for fileIdx := range repo.Files {
+			for view := range counts {
+				if m.inView(view, repoIdx, fileIdx) {
+					counts[view]++
+				}
+			}
+		}
+	}
+	return counts
+}
+
+// Titre du panel 1 : vue courante et son nombre de plugins
+func (m model) viewTitle() string {
+	if m.loading || len(m.repos) == 0 {
+		return viewLabels[viewAll]
+	}
+	counts := m.viewCounts()
+	if m.view == viewAll {
+		return fmt.Sprintf("%s (%d)", viewLabels[viewAll], counts[viewAll])
+	}
+	return fmt.Sprintf("%s (%d/%d)", viewLabels[m.view], counts[m.view], counts[viewAll])
+}